@@ -0,0 +1,122 @@
+package aurora
+
+import (
+	"bytes"
+	"io"
+)
+
+// asyncWrite is a chunk of console output queued for the writer goroutine
+// A non-nil ack asks to be closed once everything before it is written
+type asyncWrite struct {
+	buf *bytes.Buffer
+	ack chan struct{}
+}
+
+// asyncWriter moves console I/O off the Notifier mutex
+// Loggers only render and queue their line under the lock; one goroutine
+// writes the queue in order, so lines stay whole and keep their order
+type asyncWriter struct {
+	w     io.Writer
+	queue chan asyncWrite
+	done  chan struct{} // Closed by stop; the queue itself is never closed
+}
+
+// newAsyncWriter starts the goroutine writing to w with room for size chunks
+func newAsyncWriter(w io.Writer, size int) *asyncWriter {
+	a := &asyncWriter{w: w, queue: make(chan asyncWrite, size), done: make(chan struct{})}
+	go a.work()
+	return a
+}
+
+// work writes queued chunks until stop closes done
+// Write errors are dropped; there is nobody left to report them to
+func (a *asyncWriter) work() {
+	for {
+		var item asyncWrite
+		select {
+		case item = <-a.queue:
+		case <-a.done:
+			return
+		}
+		if item.buf != nil {
+			a.w.Write(item.buf.Bytes())
+			if item.buf.Cap() <= maxPooledBuffer {
+				item.buf.Reset()
+				bufferPool.Put(item.buf)
+			}
+		}
+		if item.ack != nil {
+			close(item.ack)
+		}
+	}
+}
+
+// Write queues a copy of p, blocking only while the queue is full
+// Output arriving after stop is dropped
+func (a *asyncWriter) Write(p []byte) (int, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Write(p)
+	select {
+	case a.queue <- asyncWrite{buf: buf}:
+	case <-a.done:
+	}
+	return len(p), nil
+}
+
+// flush waits until everything queued so far has been written
+// Returns early once stop ended the writer, which flushed on its way out
+func (a *asyncWriter) flush() {
+	ack := make(chan struct{})
+	select {
+	case a.queue <- asyncWrite{ack: ack}:
+	case <-a.done:
+		return
+	}
+	select {
+	case <-ack:
+	case <-a.done:
+	}
+}
+
+// stop flushes the queue and ends the writer goroutine
+// Callers hold the Notifier mutex, so stop runs at most once per writer
+func (a *asyncWriter) stop() {
+	a.flush()
+	close(a.done)
+}
+
+// SetAsync hands console writes to a background goroutine with a queue of size entries
+// Goroutines logging at once then only contend for rendering, not for slow
+// terminal or pipe I/O. Call Flush (or Close) before exiting; zero turns it off
+// and writes synchronously again. SetWriteTimeout has no effect while async
+func (n *Notifier) SetAsync(size int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s := n.output
+	if s.async != nil {
+		s.async.stop()
+		s.async = nil
+	}
+	if size > 0 {
+		s.async = newAsyncWriter(s.w, size)
+	}
+}
+
+// Flush waits until queued console output has been written
+// A no-op unless SetAsync is active
+func (n *Notifier) Flush() {
+	n.mu.Lock()
+	a := n.output.async
+	n.mu.Unlock()
+	if a != nil {
+		a.flush()
+	}
+}
+
+// Flush waits for queued console output of the default Notifier
+// Call before exiting when SetAsync is on
+func Flush() { Default.Flush() }
+
+// SetAsync moves console writes of the default Notifier to a background goroutine
+// Reduces contention between heavily logging goroutines
+func SetAsync(size int) { Default.SetAsync(size) }
@@ -1,12 +1,15 @@
 package aurora
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/fatih/color"
 	"github.com/mattes/go-asciibot"
-	"github.com/nwidger/jsoncolor"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -20,8 +23,14 @@ type LogLevel int
 const (
 	IconSuccess = "✓" // Success icon used in Success/Failure methods
 	IconError   = "✗" // Error icon used in Success/Failure methods
+	IconStep    = "→" // Step icon used in Step
+	IconWarning = "⚠" // Warning icon used in Danger boxes
 )
 
+// DefaultTimeFormat is the timestamp layout used by Logf
+// Override per Notifier with the WithTimeFormat option
+const DefaultTimeFormat = "2006-01-02 03:04:05 PM"
+
 // Indentation constants for consistent JSON formatting across the application.
 // These provide standardized ways to format JSON output while maintaining readability.
 const (
@@ -54,10 +63,16 @@ const (
 	IndentDebug = "• "
 )
 
+// levelStep is the distance between the predefined levels
+// Leaves room for custom levels, e.g. InfoLevel + 5 sorts before Notice
+const levelStep = 10
+
 // Log level constants in order of increasing severity
-// These define the available logging levels from least to most severe
+// These define the available logging levels from least to most severe;
+// DebugLevel stays zero so Trace is hidden unless asked for
 const (
-	DebugLevel LogLevel = iota
+	TraceLevel LogLevel = (iota - 1) * levelStep
+	DebugLevel
 	InfoLevel
 	NoticeLevel
 	WarnLevel
@@ -71,6 +86,7 @@ const (
 // These provide visual indicators for different log severities
 var defaultSymbols = map[LogLevel]string{
 	AlertLevel:    "[✭]", // Alert symbol for attention-grabbing messages
+	TraceLevel:    "[⋯]", // Trace symbol for fine-grained protocol output
 	InfoLevel:     "[✔]", // Info symbol for general information
 	ErrorLevel:    "[✘]", // Error symbol for error conditions
 	NoticeLevel:   "[⚑]", // Notice symbol for notable events
@@ -80,6 +96,20 @@ var defaultSymbols = map[LogLevel]string{
 	NoLevel:       "",    // No symbol for plain messages
 }
 
+// Lowercase level names used in structured output
+// These identify levels in JSON entries and configuration
+var levelNames = map[LogLevel]string{
+	TraceLevel:    "trace",
+	DebugLevel:    "debug",
+	InfoLevel:     "info",
+	NoticeLevel:   "notice",
+	WarnLevel:     "warn",
+	ErrorLevel:    "error",
+	AlertLevel:    "alert",
+	CriticalLevel: "critical",
+	NoLevel:       "none",
+}
+
 // Default colors for each log level
 // These assign distinct colors to make log levels easily distinguishable
 var defaultColors = map[LogLevel]*color.Color{
@@ -88,6 +118,7 @@ var defaultColors = map[LogLevel]*color.Color{
 	ErrorLevel:    color.New(color.FgHiRed),     // Red for errors signals problems
 	NoticeLevel:   color.New(color.FgHiYellow),  // Yellow for notices draws attention
 	DebugLevel:    color.New(color.FgHiCyan),    // Cyan for debug aids developers
+	TraceLevel:    color.New(color.FgHiBlack),   // Gray for trace keeps it in the background
 	WarnLevel:     color.New(color.FgHiMagenta), // Magenta for warnings is distinct
 	CriticalLevel: color.New(color.FgHiWhite),   // White for critical is highly visible
 	NoLevel:       nil,                          // Explicitly nil means "no color processing"
@@ -115,32 +146,113 @@ func init() {
 	ResetColors()  // Initialize colors to default values
 }
 
+// String returns the lowercase name of the level, e.g. "warn"
+// Unknown levels are rendered as "level(N)"
+func (l LogLevel) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
 // Notifier provides structured, colorful logging capabilities
 // It handles synchronization and output formatting
 type Notifier struct {
-	mu     *sync.Mutex // Protects concurrent access
-	output io.Writer   // Destination for log messages
-	prefix string      // Optional prefix for all messages
+	mu     *sync.Mutex   // Protects concurrent access
+	output *switchWriter // Destination for log messages, shared with derived Notifiers
+	prefix string        // Optional prefix for all messages, the chain joined by spaces
+	chain  []string      // Prefixes added by each With, outermost first
+	root   *Notifier     // Notifier created by New or Clone this one derives from
+	fields []Field       // Fields attached to every entry, see WithFields
+	reqID  string        // ID set by WithRequestID, also among the fields
+	align  *alignment    // Message column alignment shared with derived Notifiers
+
+	highlights []highlightRule  // Patterns styled inside every message
+	filters    []filterRule     // Suppress/Only patterns deciding which messages print
+	middleware []Middleware     // Chain added with Use, run before filters
+	dropped    *dropCounter     // Messages removed by filters, shared with derived Notifiers
+	backlog    *backlog         // Hidden verbose output kept for DumpOnError, shared with derived Notifiers
+	stats      *statsCounter    // Entry counts reported by Stats, shared with derived Notifiers
+	bursts     *burstState      // Repeated entries being folded, shared with derived Notifiers
+	escalation []escalationRule // Rules raising repeated entries to a summary
+	seen       *repeatLog       // Recent repeats checked by escalation rules, shared with derived Notifiers
+	latency    *latencyLog      // Latency calls summarized by Close, shared with derived Notifiers
+	metrics    *metricSet       // Counters and gauges in the status line, shared with derived Notifiers
+	results    *resultLog       // Step results for WriteJUnit, shared with derived Notifiers
+
+	level        LogLevel                  // Minimum level written; lower levels are skipped
+	remap        map[LogLevel]LogLevel     // Levels rewritten before filtering, see RemapLevel
+	timeFormat   string                    // Timestamp layout for Logf, DefaultTimeFormat when empty
+	stampless    bool                      // Whether Logf omits the timestamp, set by WithMinimal
+	colorMode    ColorMode                 // Whether level colors follow the terminal or are forced
+	symbols      map[LogLevel]string       // Per-Notifier symbol overrides
+	symbolPos    SymbolPosition            // Where the symbol appears, first by default
+	symbolWidth  int                       // Columns symbols are padded to, unpadded when zero
+	colors       map[LogLevel]*color.Color // Per-Notifier color overrides
+	caller       bool                      // Whether entries report the calling file and line
+	worker       string                    // Worker label of every entry, see WithWorker
+	goroutineIDs bool                      // Whether entries without a worker label show the goroutine
+	jsonFormat   bool                      // Whether entries are written as JSON objects
+	verbose      bool                      // Whether Verbose blocks are written or only kept
+	exitCodes    map[LogLevel]int          // ExitCode thresholds, defaultExitCodes when nil
+	prefixHue    bool                      // Whether prefixes get their own stable color
+	spinner      string                    // Spinner style name, "dots" when empty
+	icons        TaskIcons                 // Spinner result icons, defaults when empty
+	foldWindow   time.Duration             // Window for folding repeated entries, off when zero
+	startHooks   []func(*Notifier)         // Run once by New after the options
+	exitHooks    []func(*Notifier, Stats)  // Run by Close with the final statistics
+	dryRun       bool                      // Whether actions are tagged and Exec skips commands
+	input        *bufio.Reader             // Source of interactive answers, os.Stdin when nil
+	redacted     []string                  // Headers masked by Curl, defaultRedactedHeaders when nil
+	idGen        IDGenerator               // Request ID source for WithRequestID, ShortID when nil
+}
+
+// alignment tracks the message start column across consecutive entries
+// It is shared between a Notifier and those derived from it and guarded by their mutex
+type alignment struct {
+	enabled bool // Whether messages are padded to a common column
+	width   int  // Widest symbol/prefix lead seen so far
 }
 
 // New creates Notifier that writes to given io.Writer
 // Uses os.Stdout if writer is nil for convenience
-// Returns a pointer to the new Notifier instance
-func New(w io.Writer) *Notifier {
+// Options such as WithLevel or WithTheme configure it at construction
+func New(w io.Writer, opts ...Option) *Notifier {
 	if w == nil {
 		w = os.Stdout
 	}
-	return &Notifier{
-		mu:     &sync.Mutex{},
-		output: w,
+	lock := &sync.Mutex{}
+	n := &Notifier{
+		mu:     lock,
+		output: newSwitchWriter(w, lock),
 		prefix: "",
+		align:  &alignment{},
+
+		dropped: &dropCounter{},
+		backlog: newBacklog(defaultBacklogSize),
+		stats:   newStatsCounter(),
+		bursts:  newBurstState(),
+		seen:    newRepeatLog(),
+		latency: newLatencyLog(),
+		metrics: newMetricSet(),
+		results: &resultLog{},
+	}
+	n.root = n
+	for _, opt := range opts {
+		opt(n)
+	}
+	hooks := n.startHooks
+	n.startHooks = nil
+	for _, hook := range hooks {
+		hook(n)
 	}
+	return n
 }
 
 // Output change default output
 // Returns the default Notifier instance
 func Output(w io.Writer) *Notifier {
-	Default.output = w
+	Default.SetOutput(w)
 	return Default
 }
 
@@ -148,17 +260,53 @@ func Output(w io.Writer) *Notifier {
 // Useful for important but non-critical notifications
 func (n *Notifier) Alert(f string, a ...any) { n.Inlinef(AlertLevel, f, a...) }
 
+// AlignMessages toggles alignment of message text across consecutive entries
+// Pads after symbols and prefixes so messages start in the same column
+// The column widens whenever a longer symbol or prefix appears
+func (n *Notifier) AlignMessages(enabled bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.align.enabled = enabled
+	n.align.width = 0
+}
+
 // Br inserts a single blank line in the output
 // Helps with visual separation of log entries
 func (n *Notifier) Br() { n.Line(1) }
 
+// Clone returns a copy of the Notifier that writes to w with its own mutex
+// Level, prefix, theme and rules are copied; unlike With nothing is shared
+// Uses os.Stdout if writer is nil, matching New
+func (n *Notifier) Clone(w io.Writer) *Notifier {
+	if w == nil {
+		w = os.Stdout
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	child := n.derive()
+	child.mu = &sync.Mutex{}
+	child.output = newSwitchWriter(w, child.mu)
+	child.align = &alignment{enabled: n.align.enabled}
+	child.dropped = &dropCounter{}
+	child.backlog = newBacklog(defaultBacklogSize)
+	child.stats = newStatsCounter()
+	child.bursts = newBurstState()
+	child.seen = newRepeatLog()
+	child.latency = newLatencyLog()
+	child.metrics = newMetricSet()
+	child.results = &resultLog{}
+	child.root = child
+	return child
+}
+
 // Color writes a message with specific color, ignoring log level colors
 // Useful for special messages that need distinct coloring
 // Bypasses the default level-based coloring system
 func (n *Notifier) Color(c *color.Color, format string, args ...any) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	c.Fprint(n.output, fmt.Sprintf(format, args...))
+	fmt.Fprint(n.output, n.colorMode.apply(c.Sprint(fmt.Sprintf(format, args...))))
 }
 
 // Critical logs a message at Critical level
@@ -169,6 +317,10 @@ func (n *Notifier) Critical(f string, a ...any) { n.Inlinef(CriticalLevel, f, a.
 // Intended for developer-facing diagnostic information
 func (n *Notifier) Debug(f string, a ...any) { n.Inlinef(DebugLevel, f, a...) }
 
+// Trace logs a message at Trace level, below Debug
+// For verbose output such as protocol traces; enable with WithLevel(TraceLevel)
+func (n *Notifier) Trace(f string, a ...any) { n.Inlinef(TraceLevel, f, a...) }
+
 // Error logs a message at Error level
 // Indicates problems that need attention
 func (n *Notifier) Error(f string, a ...any) { n.Inlinef(ErrorLevel, f, a...) }
@@ -184,7 +336,8 @@ func (n *Notifier) Failure(format string, args ...any) {
 // Exists the application after logging the error
 // Useful for terminating the program with an error message
 func (n *Notifier) Fatal(args ...any) {
-	colors[ErrorLevel].Fprint(n.output, fmt.Sprint(args...))
+	fmt.Fprint(n.output, n.colorMode.apply(paint(n.color(ErrorLevel), fmt.Sprint(args...))))
+	n.Flush()
 	os.Exit(1)
 }
 
@@ -194,7 +347,7 @@ func (n *Notifier) Fatal(args ...any) {
 func (n *Notifier) Format(formatter Formater, format string, args ...any) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	colors[NoLevel].Fprint(n.output, formatter(format, args...))
+	fmt.Fprint(n.output, formatter(format, args...))
 }
 
 // Func executes function and writes output with specified log level color
@@ -203,7 +356,7 @@ func (n *Notifier) Format(formatter Formater, format string, args ...any) {
 func (n *Notifier) Func(level LogLevel, fn func() string) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	colors[level].Fprint(n.output, fn())
+	fmt.Fprint(n.output, n.colorMode.apply(paint(n.color(level), fn())))
 }
 
 // Highlight writes text with yellow background highlight
@@ -231,51 +384,58 @@ func (n *Notifier) JSON(values ...any) {
 	n.JSONIndent("", IndentNone, values...)
 }
 
+// JSONE logs JSON data without title and returns any marshal error
+// Lets library code react to serialization failures
+func (n *Notifier) JSONE(values ...any) error {
+	return n.JSONIndentE("", IndentNone, values...)
+}
+
 // JSONTitle logs JSON data with title (no indentation)
 func (n *Notifier) JSONTitle(title string, values ...any) {
 	n.JSONIndent(title, IndentSpace2, values...)
 }
 
 // JSONIndent logs JSON data with custom indentation
+// Marshal errors are logged unless disabled with SetMarshalErrorLogging
 func (n *Notifier) JSONIndent(title string, indent string, values ...any) {
+	n.marshalFailed(n.JSONIndentE(title, indent, values...))
+}
+
+// JSONIndentE logs JSON data with custom indentation and returns marshal errors
+// Values that fail are skipped while the rest are still written
+func (n *Notifier) JSONIndentE(title string, indent string, values ...any) error {
 	if title != "" {
 		n.Inlinef(DebugLevel, "%s: JSON ↴↴", title)
 	}
-	n.mu.Lock()
-	defer n.mu.Unlock()
 
-	formatter := jsoncolor.NewFormatter()
-	formatter.Indent = indent
+	var out bytes.Buffer
+	var errs []error
 	for _, v := range values {
-		data, err := jsoncolor.MarshalIndent(v, "", indent)
+		data, err := marshalJSON(v, indent)
 		if err != nil {
-			n.Logf(ErrorLevel, "failed to marshal JSON: %v", err)
+			errs = append(errs, fmt.Errorf("failed to marshal JSON: %w", err))
 			continue
 		}
-		n.output.Write(data)
-		n.output.Write([]byte{'\n'})
+		out.Write(data)
+		out.WriteByte('\n')
 	}
-	n.output.Write([]byte{'\n'})
+	out.WriteByte('\n')
+	n.writeBlock(out.String())
+	return errors.Join(errs...)
 }
 
 // Inlinef writes single-line log without timestamp
 // Ideal for compact output where timestamps aren't needed
 // Includes level symbol and color
 func (n *Notifier) Inlinef(level LogLevel, format string, args ...any) {
+	if !n.enabled(level) {
+		return
+	}
+	e := n.newEntry(level, sprintf(format, args))
+
 	n.mu.Lock()
 	defer n.mu.Unlock()
-
-	symbol := symbols[level]
-	msg := fmt.Sprintf(format, args...)
-	msg = n.formatWithPrefix(msg)
-	line := fmt.Sprintf("%s %s\n", symbol, msg)
-
-	// Handle NoLevel specially (raw output)
-	if level == NoLevel {
-		fmt.Fprint(n.output, line) // No color processing
-	} else {
-		colors[level].Fprint(n.output, line)
-	}
+	n.emit(e)
 }
 
 // Line inserts specified number of blank lines
@@ -291,16 +451,15 @@ func (n *Notifier) Line(count int) {
 // Provides complete log message with all standard fields
 // Includes timestamp for temporal context
 func (n *Notifier) Logf(level LogLevel, format string, args ...any) {
+	if !n.enabled(level) {
+		return
+	}
+	e := n.newEntry(level, sprintf(format, args))
+	e.stamped = true
+
 	n.mu.Lock()
 	defer n.mu.Unlock()
-
-	timestamp := time.Now().Format("2006-01-02 03:04:05 PM")
-	symbol := symbols[level]
-	msg := fmt.Sprintf(format, args...)
-	msg = n.formatWithPrefix(msg)
-	line := fmt.Sprintf("%s %s %s\n", symbol, timestamp, msg)
-
-	colors[level].Fprint(n.output, line)
+	n.emit(e)
 }
 
 // Notice logs a message at Notice level
@@ -312,6 +471,7 @@ func (n *Notifier) Notice(f string, a ...any) { n.Inlinef(NoticeLevel, f, a...)
 func (n *Notifier) Panic(f string, a ...any) {
 	msg := fmt.Sprintf(f, a...)
 	n.Inlinef(CriticalLevel, msg)
+	n.Flush()
 	panic(msg)
 }
 
@@ -319,34 +479,46 @@ func (n *Notifier) Panic(f string, a ...any) {
 // Maintains prefix and color while being more minimal
 // Useful for simple formatted output
 func (n *Notifier) Printf(level LogLevel, format string, args ...any) {
+	if !n.enabled(level) {
+		return
+	}
+	e := n.newEntry(level, sprintf(format, args))
+	e.plain = true
+
 	n.mu.Lock()
 	defer n.mu.Unlock()
-
-	msg := fmt.Sprintf(format, args...)
-	msg = n.formatWithPrefix(msg)
-	line := fmt.Sprintf("%s\n", msg)
-
-	if level == NoLevel {
-		fmt.Fprint(n.output, line) // Raw output
-	} else {
-		colors[level].Fprint(n.output, line)
-	}
+	n.emit(e)
 }
 
 // Robot displays random ASCII robot art
 // Adds fun visual element to console output
-// Makes logs more engaging
+// Written to the console only so file sinks stay clean
 func (n *Notifier) Robot(level LogLevel) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	colors[level].Fprint(n.output, fmt.Sprintf("%s\n", asciibot.Random()))
+	robot := n.colorMode.apply(paint(n.color(level), fmt.Sprintf("%s\n", asciibot.Random())))
+	n.output.route(ConsoleSink, []byte(robot), nil)
+}
+
+// SetColorMode changes whether the Notifier colors its output
+// Like WithColorMode but in place, for flags parsed after the Notifier was
+// created. Not synchronized: call it before logging starts or With is used
+func (n *Notifier) SetColorMode(mode ColorMode) {
+	n.colorMode = mode
+}
+
+// SetLevel changes the minimum level the Notifier writes
+// Like WithLevel but in place and, like SetColorMode, only safe to call
+// before other goroutines log; Notifiers derived earlier keep their level
+func (n *Notifier) SetLevel(level LogLevel) {
+	n.level = level
 }
 
 // Success prints success message with green color and checkmark
 // Standardized way to indicate successful operations
 // Uses InfoLevel for positive feedback
 func (n *Notifier) Success(format string, args ...any) {
-	n.Inlinef(InfoLevel, n.f(IconSuccess, " ", format), args...)
+	n.action(InfoLevel, n.f(IconSuccess, " ", format), args...)
 }
 
 // Warn logs a message at Warn level
@@ -357,26 +529,112 @@ func (n *Notifier) Warn(f string, a ...any) { n.Inlinef(WarnLevel, f, a...) }
 // Enables contextual logging with shared configuration
 // Maintains original Notifier's output and synchronization
 func (n *Notifier) With(prefix string) *Notifier {
-	newPrefix := prefix
-	if n.prefix != "" {
-		newPrefix = fmt.Sprintf("%s %s", n.prefix, prefix)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	child := n.derive()
+	child.chain = append(slices.Clip(n.chain), prefix)
+	child.prefix = strings.Join(child.chain, " ")
+	return child
+}
+
+// Prefixes returns the prefixes added by each With, outermost first
+// Lets frameworks inspect or flatten deeply nested Notifiers
+func (n *Notifier) Prefixes() []string {
+	return slices.Clone(n.chain)
+}
+
+// Root returns the Notifier created by New or Clone that n derives from
+// Returns n itself when it was not derived with With or WithOptions
+func (n *Notifier) Root() *Notifier {
+	return n.root
+}
+
+// derive returns a shallow copy of n sharing its output and mutex
+// Base for With and WithOptions; slices and maps are copied on write
+// Callers must hold the mutex, since setters change n in place
+func (n *Notifier) derive() *Notifier {
+	child := *n
+	return &child
+}
+
+// color returns the color for level, honoring per-Notifier overrides
+// NoLevel and levels configured without color yield nil
+func (n *Notifier) color(level LogLevel) *color.Color {
+	if level == NoLevel {
+		return nil
+	}
+	c, ok := n.colors[level]
+	if !ok {
+		c = colors[level]
 	}
-	return &Notifier{
-		mu:     n.mu,
-		output: n.output,
-		prefix: newPrefix,
+	return n.force(c)
+}
+
+// force returns a copy of c that always emits color under ColorAlways
+// Other modes return c unchanged
+func (n *Notifier) force(c *color.Color) *color.Color {
+	if c != nil && n.colorMode == ColorAlways {
+		forced := *c
+		forced.EnableColor()
+		return &forced
 	}
+	return c
+}
+
+// colored reports whether the Notifier's output carries color
+// ColorAlways wins over color.NoColor; ColorNever and porcelain mode strip it
+func (n *Notifier) colored() bool {
+	return !n.colorMode.strips() && (n.colorMode == ColorAlways || !color.NoColor)
+}
+
+// enabled reports whether entries at level pass the minimum level
+// NoLevel output is never filtered
+func (n *Notifier) enabled(level LogLevel) bool {
+	level = n.mapped(level)
+	return level == NoLevel || level >= n.level
+}
+
+// symbol returns the symbol for level, honoring per-Notifier overrides
+func (n *Notifier) symbol(level LogLevel) string {
+	if s, ok := n.symbols[level]; ok {
+		return s
+	}
+	return symbols[level]
+}
+
+// timestamp formats t with the configured layout in the active locale
+// Narrow terminals use CompactTimeFormat unless a layout was configured
+func (n *Notifier) timestamp(t time.Time) string {
+	layout := n.timeFormat
+	if layout == "" && compact() {
+		layout = CompactTimeFormat
+	}
+	if c := n.output.clock.Load(); c != nil {
+		return c.format(t, layout)
+	}
+	return localTime(t, layout)
 }
 
 // formatWithPrefix adds the configured prefix to messages
 // Internal helper method for consistent prefix handling
 func (n *Notifier) formatWithPrefix(msg string) string {
 	if n.prefix != "" {
-		return fmt.Sprintf("[%s] %s", n.prefix, msg)
+		return "[" + n.prefix + "] " + msg
 	}
 	return msg
 }
 
+// sprintf formats a message, skipping fmt when there is nothing to format
+// Keeps constant messages such as Info("ready") free of allocations
+func sprintf(format string, args []any) string {
+	if len(args) == 0 && strings.IndexByte(format, '%') < 0 {
+		return format
+	}
+	msg := fmt.Sprintf(format, args...)
+	vetFormat(format, msg)
+	return msg
+}
+
 // f concatenates multiple arguments into a single string
 // Internal helper for building formatted messages
 func (n *Notifier) f(args ...any) string {
@@ -409,6 +667,10 @@ func Critical(f string, a ...any) { Default.Critical(f, a...) }
 // Quick debugging output
 func Debug(f string, a ...any) { Default.Debug(f, a...) }
 
+// Trace logs a message at Trace level using default Notifier
+// Hidden unless the level is lowered to TraceLevel
+func Trace(f string, a ...any) { Default.Trace(f, a...) }
+
 // Error logs a message at Error level using default Notifier
 // Simple error reporting
 func Error(f string, a ...any) { Default.Error(f, a...) }
@@ -455,6 +717,10 @@ func Inlinef(level LogLevel, f string, a ...any) { Default.Inlinef(level, f, a..
 // Structured data logging shortcut for compact output
 func JSON(v ...any) { Default.JSON(v...) }
 
+// JSONE logs JSON data using default Notifier and returns marshal errors
+// Structured data logging for callers that handle failures
+func JSONE(v ...any) error { return Default.JSONE(v...) }
+
 // JSONTitle logs JSON data with title using default Notifier (no indentation)
 // Structured data logging with title for context
 func JSONTitle(title string, v ...any) { Default.JSONTitle(title, v...) }
@@ -496,6 +762,14 @@ func Robot(l LogLevel) { Default.Robot(l) }
 // RobotAscii just a simple helper
 func RobotAscii() string { return asciibot.Random() }
 
+// SetColorMode changes whether the default Notifier colors its output
+// Usually wired to a --no-color flag; call it before logging starts
+func SetColorMode(mode ColorMode) { Default.SetColorMode(mode) }
+
+// SetLevel changes the minimum level of the default Notifier
+// Usually wired to --verbose or --quiet flags; call it before logging starts
+func SetLevel(level LogLevel) { Default.SetLevel(level) }
+
 // Success logs success message with checkmark using default Notifier
 // Positive feedback shortcut
 func Success(format string, args ...any) {
@@ -506,6 +780,10 @@ func Success(format string, args ...any) {
 // Warning notification shortcut
 func Warn(f string, a ...any) { Default.Warn(f, a...) }
 
+// AlignMessages toggles message column alignment on the default Notifier
+// Keeps mixed prefix lengths easy to scan
+func AlignMessages(enabled bool) { Default.AlignMessages(enabled) }
+
 // With creates new Notifier with prefix using default Notifier
 // Contextual logging setup
 func With(prefix string) *Notifier { return Default.With(prefix) }
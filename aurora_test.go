@@ -2,8 +2,25 @@ package aurora
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
 	"github.com/fatih/color"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -201,6 +218,29 @@ func TestConcurrentInlinef(t *testing.T) {
 	}
 }
 
+// TestConcurrentDerive tests deriving Notifiers while settings change
+// Meaningful under the race detector
+func TestConcurrentDerive(t *testing.T) {
+	n := New(io.Discard)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			n.SetVerbose(i%2 == 0)
+			n.Suppress("noise")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			n.With("db").WithFields(Fields{"id": i}).WithOptions(WithCaller()).Info("query")
+		}
+	}()
+	wg.Wait()
+}
+
 // TestRobot tests the Robot method for ASCII art
 func TestRobot(t *testing.T) {
 	color.NoColor = true
@@ -259,3 +299,3136 @@ func TestDefaultNotifier(t *testing.T) {
 		t.Errorf("Default Info() expected '[✔] Default test', got: %q", output)
 	}
 }
+
+// TestAlignMessages tests that messages share a column across prefix lengths
+func TestAlignMessages(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.AlignMessages(true)
+
+	n.With("database").Info("connected")
+	n.With("api").Info("listening")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	if got, want := strings.Index(lines[1], "listening"), strings.Index(lines[0], "connected"); got != want {
+		t.Errorf("AlignMessages() message columns differ: %q vs %q", lines[0], lines[1])
+	}
+}
+
+// TestTabbed tests that tab separated cells are aligned on Flush
+func TestTabbed(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	tw := n.Tabbed(NoLevel)
+	tw.Write([]byte("NAME\tSTATUS\n"))
+	tw.Write([]byte("\x1b[32mapi-server\x1b[0m\tup\ndb\tdown"))
+	if buf.Len() != 0 {
+		t.Fatalf("Tabbed() wrote before Flush: %q", buf.String())
+	}
+	tw.Flush()
+
+	want := "NAME        STATUS\n\x1b[32mapi-server\x1b[0m  up\ndb          down\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Tabbed() = %q, want %q", got, want)
+	}
+}
+
+type textID int
+
+func (id textID) MarshalText() ([]byte, error) { return []byte(fmt.Sprintf("id-%d", int(id))), nil }
+
+type fakeProto struct{ Name string }
+
+func (fakeProto) ProtoReflect() {}
+
+// TestJSONMarshalers tests that custom marshalers drive JSON output
+func TestJSONMarshalers(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	SetProtoMarshaler(func(v any) ([]byte, error) {
+		return []byte(`{"proto":"` + v.(fakeProto).Name + `"}`), nil
+	})
+	defer SetProtoMarshaler(nil)
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.JSON(textID(7), fakeProto{Name: "ping"})
+
+	output := regexp.MustCompile(`\x1b\[[0-9;]*m`).ReplaceAllString(buf.String(), "")
+	if !strings.Contains(output, `"id-7"`) {
+		t.Errorf("JSON() expected TextMarshaler output, got %q", output)
+	}
+	if !strings.Contains(output, `"proto":"ping"`) {
+		t.Errorf("JSON() expected proto marshaler output, got %q", output)
+	}
+
+	buf.Reset()
+	n.JSON((*time.Time)(nil))
+	if got := buf.String(); got != "null\n\n" {
+		t.Errorf("JSON() of a nil pointer = %q, want null", got)
+	}
+	if got := Preview((*time.Time)(nil), 0).String(); got != "null" {
+		t.Errorf("Preview() of a nil pointer = %q, want null", got)
+	}
+
+	// Only the top-level value goes through the proto marshaler
+	buf.Reset()
+	n.JSON(map[string]any{"msg": fakeProto{Name: "ping"}})
+	if output := StripANSI(buf.String()); strings.Contains(output, `"proto"`) || !strings.Contains(output, `"Name":"ping"`) {
+		t.Errorf("JSON() of a nested message = %q, want its struct fields", output)
+	}
+}
+
+// TestJSONFilter tests inclusive and exclusive path filtering
+func TestJSONFilter(t *testing.T) {
+	doc := map[string]any{
+		"user":  map[string]any{"name": "bob", "password": "hunter2", "age": 32},
+		"items": []any{map[string]any{"id": 1, "sku": "a"}, map[string]any{"id": 2, "sku": "b"}},
+		"trace": "noise",
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{"include", []string{"user.name", "items.*.id"}, `{"items":[{"id":1},{"id":2}],"user":{"name":"bob"}}`},
+		{"exclude", []string{"-user.password", "-trace", "-items.0"}, `{"items":[{"id":2,"sku":"b"}],"user":{"age":32,"name":"bob"}}`},
+		{"index", []string{"items.1.sku"}, `{"items":[{"sku":"b"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterJSON(doc, tt.paths)
+			if err != nil {
+				t.Fatalf("filterJSON() error = %v", err)
+			}
+			data, _ := json.Marshal(got)
+			if string(data) != tt.want {
+				t.Errorf("filterJSON() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+// TestDumpLimits tests the depth and size guards on JSON output
+func TestDumpLimits(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	t.Run("depth", func(t *testing.T) {
+		SetMaxDepth(2)
+		defer SetMaxDepth(0)
+
+		var buf bytes.Buffer
+		n := New(&buf)
+		n.JSON(map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}}, "list": []int{1, 2, 3}})
+
+		output := buf.String()
+		if !strings.Contains(output, `"b":"{… 1 keys}"`) || !strings.Contains(output, `"list":[1,2,3]`) {
+			t.Errorf("JSON() with depth limit got %q", output)
+		}
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		SetMaxDumpBytes(40)
+		defer SetMaxDumpBytes(0)
+
+		items := make([]int, 100)
+		var buf bytes.Buffer
+		n := New(&buf)
+		n.JSONTitle("", items)
+
+		output := buf.String()
+		if !strings.Contains(output, "… truncated (") {
+			t.Errorf("JSON() expected truncation notice, got %q", output)
+		}
+		if strings.Count(output, "\n") > 10 {
+			t.Errorf("JSON() expected short output, got %d lines", strings.Count(output, "\n"))
+		}
+	})
+
+	t.Run("escapes", func(t *testing.T) {
+		SetMaxDumpBytes(10)
+		defer SetMaxDumpBytes(0)
+
+		short := []byte("\x1b[32mshort\x1b[0m")
+		if got := limitDump(short); !bytes.Equal(got, short) {
+			t.Errorf("limitDump() cut colored text within the limit: %q", got)
+		}
+		line := []byte(strings.Repeat("\x1b[32mab\x1b[0m,", 20))
+		first, _, _ := strings.Cut(string(limitDump(line)), "\n")
+		if plain := StripANSI(first); plain != "ab,ab,ab,a" {
+			t.Errorf("limitDump() kept %q of the visible text, want 10 bytes", plain)
+		}
+	})
+}
+
+// TestJSONE tests that marshal errors are returned and logging can be disabled
+func TestJSONE(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	if err := n.JSONE(map[string]any{"ok": true}, make(chan int)); err == nil {
+		t.Errorf("JSONE() expected error for channel value")
+	}
+	if !strings.Contains(buf.String(), `"ok":true`) {
+		t.Errorf("JSONE() expected valid values to be written, got %q", buf.String())
+	}
+
+	buf.Reset()
+	SetMarshalErrorLogging(false)
+	defer SetMarshalErrorLogging(true)
+	n.JSON(make(chan int))
+	if strings.Contains(buf.String(), "failed to marshal") {
+		t.Errorf("JSON() logged error with logging disabled: %q", buf.String())
+	}
+}
+
+// TestHighlightRule tests styling of pattern matches inside messages
+func TestHighlightRule(t *testing.T) {
+	color.NoColor = false
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.HighlightRule(`req-\d+`, NewStyle(color.FgRed))
+	sub := n.With("api")
+	n.HighlightRule(`ok`, NewStyle(color.Bold))
+
+	sub.Info("handled req-42 ok")
+
+	output := buf.String()
+	if !strings.Contains(output, "\x1b[31mreq-42\x1b[0m") {
+		t.Errorf("HighlightRule() expected styled match, got %q", output)
+	}
+	if strings.Contains(output, "\x1b[1mok") {
+		t.Errorf("HighlightRule() rule added after With leaked into child: %q", output)
+	}
+	if !strings.Contains(output, "\x1b[92mhandled \x1b[0m") {
+		t.Errorf("HighlightRule() expected level color around plain text, got %q", output)
+	}
+}
+
+// TestSuppressOnly tests message filtering and the Close report
+func TestSuppressOnly(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Only(`^deploy`)
+	n.Suppress(`heartbeat`)
+
+	n.Info("deploy started")
+	n.Info("cache warmed")
+	n.Info("deploy heartbeat")
+	n.Close()
+
+	output := buf.String()
+	if !strings.Contains(output, "deploy started") {
+		t.Errorf("Only() dropped a matching message: %q", output)
+	}
+	if strings.Contains(output, "cache warmed") || strings.Contains(output, "deploy heartbeat") {
+		t.Errorf("filters let a message through: %q", output)
+	}
+	if !strings.Contains(output, "2 lines suppressed") {
+		t.Errorf("Close() expected suppression report, got %q", output)
+	}
+}
+
+// TestAutoLevelWriter tests keyword based level detection
+func TestAutoLevelWriter(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	w := n.AutoLevelWriter()
+	fmt.Fprint(w, "2025/01/02 [ERROR] connection refused\n")
+	fmt.Fprint(w, "time=now level=warn msg=slow\n")
+	fmt.Fprint(w, "panic: runtime error\n")
+	fmt.Fprint(w, "just text")
+	w.Close()
+
+	want := "[✘] 2025/01/02 [ERROR] connection refused\n" +
+		"[⚠] time=now level=warn msg=slow\n" +
+		"[‼] panic: runtime error\n" +
+		"just text\n"
+	if got := buf.String(); got != want {
+		t.Errorf("AutoLevelWriter() = %q, want %q", got, want)
+	}
+}
+
+// TestWithOptions tests scoped configuration on derived Notifiers
+func TestWithOptions(t *testing.T) {
+	color.NoColor = false
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	quiet := n.WithOptions(
+		WithLevel(WarnLevel),
+		WithSymbol(WarnLevel, "[W]"),
+		WithTimeFormat("15:04"),
+		WithColorMode(ColorNever),
+	)
+
+	quiet.Info("hidden")
+	quiet.Warn("careful")
+	quiet.Logf(ErrorLevel, "stamped")
+	n.Warn("parent")
+
+	output := buf.String()
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", output)
+	}
+	if lines[0] != "[W] careful" {
+		t.Errorf("WithOptions() line = %q, want %q", lines[0], "[W] careful")
+	}
+	if !regexp.MustCompile(`^\[✘\] \d\d:\d\d stamped$`).MatchString(lines[1]) {
+		t.Errorf("WithTimeFormat() line = %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "[⚠] parent") || !strings.Contains(lines[2], "\x1b[") {
+		t.Errorf("parent Notifier was affected by WithOptions: %q", lines[2])
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf,
+		WithLevel(InfoLevel),
+		WithTheme(Theme{Symbols: map[LogLevel]string{InfoLevel: "(i)"}}),
+		WithCaller(),
+	)
+	n.Debug("hidden")
+	n.Info("ready")
+
+	if !regexp.MustCompile(`^\(i\) aurora_test\.go:\d+ ready\n$`).MatchString(buf.String()) {
+		t.Errorf("New() with options wrote %q", buf.String())
+	}
+
+	buf.Reset()
+	j := New(&buf, WithJSONFormat()).With("api")
+	j.Warn("slow %s", "query")
+
+	var got map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WithJSONFormat() wrote invalid JSON %q: %v", buf.String(), err)
+	}
+	if got["level"] != "warn" || got["prefix"] != "api" || got["msg"] != "slow query" || got["time"] == "" {
+		t.Errorf("WithJSONFormat() entry = %v", got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var first, second bytes.Buffer
+	n := New(&first, WithLevel(WarnLevel)).With("db")
+	clone := n.Clone(&second)
+
+	clone.Info("hidden")
+	clone.Warn("slow")
+	n.Warn("original")
+
+	if got := second.String(); got != "[⚠] [db] slow\n" {
+		t.Errorf("Clone() output = %q", got)
+	}
+	if got := first.String(); got != "[⚠] [db] original\n" {
+		t.Errorf("original output = %q", got)
+	}
+	if clone.mu == n.mu {
+		t.Error("Clone() shares the mutex with the original")
+	}
+}
+
+func TestSwapOutput(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var first, second bytes.Buffer
+	n := New(&first)
+	child := n.With("worker")
+
+	n.Info("before")
+	if old := n.SwapOutput(&second); old != &first {
+		t.Errorf("SwapOutput() returned %v, want the previous writer", old)
+	}
+	child.Info("after")
+
+	if got := first.String(); got != "[✔] before\n" {
+		t.Errorf("first output = %q", got)
+	}
+	if got := second.String(); got != "[✔] [worker] after\n" {
+		t.Errorf("second output = %q, derived Notifier did not follow SwapOutput", got)
+	}
+}
+
+func TestHoldRelease(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	n.Hold()
+	n.With("step").Info("compiled")
+	if buf.Len() != 0 {
+		t.Fatalf("Hold() let output through: %q", buf.String())
+	}
+	n.Discard()
+	if buf.Len() != 0 {
+		t.Fatalf("Discard() wrote held output: %q", buf.String())
+	}
+
+	n.Hold()
+	n.Error("link failed")
+	n.Release()
+	n.Info("done")
+	if got, want := buf.String(), "[✘] link failed\n[✔] done\n"; got != want {
+		t.Errorf("Release() output = %q, want %q", got, want)
+	}
+}
+
+func TestVerbose(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	n.Verbose(func(v *Notifier) { v.Debug("resolved %d packages", 3) })
+	n.DumpOnError(nil)
+	if buf.Len() != 0 {
+		t.Fatalf("hidden Verbose() block was written: %q", buf.String())
+	}
+
+	n.DumpOnError(errors.New("build failed"))
+	if got, want := buf.String(), "[⚑] verbose output before error:\n[⧳] resolved 3 packages\n"; got != want {
+		t.Errorf("DumpOnError() output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	n.SetVerbose(true)
+	n.Verbose(func(v *Notifier) { v.Debug("shown") })
+	n.DumpOnError(errors.New("again"))
+	if got, want := buf.String(), "[⧳] shown\n"; got != want {
+		t.Errorf("verbose output = %q, want %q", got, want)
+	}
+}
+
+func TestSinkRouting(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var console, file bytes.Buffer
+	n := New(&console)
+	n.AddSink(FileSink, &file)
+
+	n.At(InfoLevel).Only(ConsoleSink).Msg("banner")
+	n.At(WarnLevel).Only(FileSink).Msg("audit %d", 7)
+	n.With("db").Error("down")
+
+	if got, want := console.String(), "[✔] banner\n[✘] [db] down\n"; got != want {
+		t.Errorf("console output = %q, want %q", got, want)
+	}
+	if got, want := file.String(), "[⚠] audit 7\n[✘] [db] down\n"; got != want {
+		t.Errorf("file output = %q, want %q", got, want)
+	}
+}
+
+func TestMirrorPlain(t *testing.T) {
+	color.NoColor = false
+
+	var console, file bytes.Buffer
+	n := New(&console, WithColorMode(ColorAlways))
+	n.MirrorPlain(&file)
+
+	n.With("db").Warn("slow %s", "query")
+	n.Printf(InfoLevel, "plain")
+	n.Color(color.New(color.FgRed), "raw\n")
+
+	if !strings.Contains(console.String(), "\x1b[") {
+		t.Errorf("console output lost its colors: %q", console.String())
+	}
+	if got, want := file.String(), "[WARN] [db] slow query\nplain\nraw\n"; got != want {
+		t.Errorf("MirrorPlain() output = %q, want %q", got, want)
+	}
+}
+
+func TestStats(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Suppress("noise")
+
+	n.Warn("disk at 80%%")
+	n.Warn("disk at 90%%")
+	n.With("db").Error("connection lost")
+	n.Info("noise")
+
+	stats := n.Stats()
+	if stats.Counts[WarnLevel] != 2 || stats.Counts[ErrorLevel] != 1 || stats.Dropped != 1 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	if stats.Bytes != int64(buf.Len()) {
+		t.Errorf("Stats().Bytes = %d, want %d", stats.Bytes, buf.Len())
+	}
+	if stats.LastError.IsZero() || !stats.LastCritical.IsZero() {
+		t.Errorf("Stats() timestamps = %v, %v", stats.LastError, stats.LastCritical)
+	}
+	if got, want := stats.String(), "2 warnings, 1 error"; got != want {
+		t.Errorf("Stats().String() = %q, want %q", got, want)
+	}
+	n.Logf(ErrorLevel+5, "custom severity")
+	n.Logf(NoLevel, "plain")
+	if got := n.Stats().Errors(); got != 2 {
+		t.Errorf("Stats().Errors() = %d, want 2 with a custom level above Error", got)
+	}
+
+	n.ResetStats()
+	if stats := n.Stats(); len(stats.Counts) != 0 || stats.Bytes != 0 || stats.Dropped != 0 {
+		t.Errorf("ResetStats() left %+v", stats)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Info("fine")
+	if code := n.ExitCode(); code != 0 {
+		t.Errorf("ExitCode() after info = %d, want 0", code)
+	}
+	n.Warn("careful")
+	if code := n.ExitCode(); code != 1 {
+		t.Errorf("ExitCode() after warning = %d, want 1", code)
+	}
+	n.Critical("down")
+	if code := n.ExitCode(); code != 2 {
+		t.Errorf("ExitCode() after critical = %d, want 2", code)
+	}
+
+	strict := New(&buf, WithExitCodes(map[LogLevel]int{ErrorLevel: 3}))
+	strict.Warn("careful")
+	if code := strict.ExitCode(); code != 0 {
+		t.Errorf("ExitCode() with custom codes after warning = %d, want 0", code)
+	}
+	strict.Error("failed")
+	if code := strict.ExitCode(); code != 3 {
+		t.Errorf("ExitCode() with custom codes after error = %d, want 3", code)
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+	if err := SetLocale("de"); err != nil {
+		t.Fatalf("SetLocale(de) error = %v", err)
+	}
+	defer SetLocale("en")
+
+	var console, file bytes.Buffer
+	n := New(&console, WithTimeFormat("Monday 2 January"))
+	n.MirrorPlain(&file)
+	n.Suppress("noise")
+	n.Warn("noise")
+	n.Warn("noise")
+	n.Close()
+	n.Logf(InfoLevel, "stamped")
+
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	if len(lines) != 2 || lines[0] != "[HINWEIS] 2 Zeilen unterdrückt" {
+		t.Fatalf("translated output = %q", file.String())
+	}
+	want := localTime(time.Now(), "Monday 2 January")
+	if lines[1] != "[INFO] "+want+" stamped" || strings.Contains(want, "day") {
+		t.Errorf("translated timestamp line = %q", lines[1])
+	}
+	if got := German.Months[time.Now().Month()-1]; !strings.Contains(lines[1], got) {
+		t.Errorf("timestamp %q lacks month %q", lines[1], got)
+	}
+	may := time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC)
+	if got := localTime(may, "Monday, Jan 2 (Mayday)"); got != "Montag, Mai 6 (Mayday)" {
+		t.Errorf("localTime() = %q, want only layout tokens translated", got)
+	}
+
+	if err := SetLocale("xx"); err == nil {
+		t.Error("SetLocale(xx) returned no error for an unknown locale")
+	}
+}
+
+func TestPrefixColors(t *testing.T) {
+	color.NoColor = false
+
+	var buf bytes.Buffer
+	n := New(&buf, WithPrefixColors(), WithColorMode(ColorAlways))
+	n.With("api").Info("request")
+	n.With("api").Warn("slow")
+
+	api := PrefixColor("api")
+	if api != PrefixColor("api") {
+		t.Error("PrefixColor() is not stable for the same prefix")
+	}
+	tag := n.force(api).Sprint("[api]")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for _, line := range lines {
+		if !strings.Contains(line, tag) {
+			t.Errorf("line %q lacks prefix colored %q", line, tag)
+		}
+	}
+
+	SetPrefixColor("db", color.New(color.FgRed))
+	defer SetPrefixColor("db", nil)
+	buf.Reset()
+	n.With("db").Info("query")
+	if want := n.force(color.New(color.FgRed)).Sprint("[db]"); !strings.Contains(buf.String(), want) {
+		t.Errorf("SetPrefixColor() not applied: %q", buf.String())
+	}
+}
+
+func TestMux(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	m := New(&buf).Mux()
+	web, worker := m.Stream("web"), m.Stream("worker")
+
+	fmt.Fprintln(web, "listening")
+	fmt.Fprint(worker, "job 1 do")
+	fmt.Fprintln(worker, "ne")
+	m.Go("db", func(w io.Writer) error {
+		fmt.Fprint(w, "ready")
+		return errors.New("exited")
+	})
+	err := m.Wait()
+
+	want := "web    | listening\nworker | job 1 done\ndb     | ready\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Mux output = %q, want %q", got, want)
+	}
+	if err == nil || err.Error() != "db: exited" {
+		t.Errorf("Wait() error = %v, want db: exited", err)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf syncBuffer
+	n := New(&buf)
+	ctx, cancel := context.WithCancel(context.Background())
+	n.Heartbeat(ctx, 10*time.Millisecond, func() string { return "indexing" })
+
+	time.Sleep(55 * time.Millisecond)
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	beats := strings.Count(buf.String(), "indexing (")
+	time.Sleep(30 * time.Millisecond)
+
+	if beats < 2 {
+		t.Errorf("Heartbeat() wrote %d lines, want at least 2: %q", beats, buf.String())
+	}
+	if after := strings.Count(buf.String(), "indexing ("); after != beats {
+		t.Errorf("Heartbeat() kept running after cancel: %d then %d lines", beats, after)
+	}
+
+	// A hidden Verbose block has no live region of its own to clear
+	n.Verbose(func(v *Notifier) {
+		done, stop := context.WithCancel(context.Background())
+		stop()
+		v.beat(done, time.Millisecond, nil)
+		v.Counter("files").Inc()
+		v.Close()
+	})
+}
+
+func TestProgress(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	p := n.Progress("download", 4<<20, WithUnit(UnitBytes),
+		WithTemplate("{{.Label}} {{.Percent}} {{.Current}}/{{.Total}}"))
+	p.Write(make([]byte, 1<<20))
+	p.Add(1 << 20)
+	p.Done()
+
+	want := "[✔] download 25% 1.0 MB/4.0 MB\n[✔] download 50% 2.0 MB/4.0 MB\n[✔] download 100% 4.0 MB/4.0 MB\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Progress output = %q, want %q", got, want)
+	}
+}
+
+func TestEstimators(t *testing.T) {
+	start := time.Now()
+	for _, e := range []Estimator{EWMA(0.5), WindowAverage(time.Minute)} {
+		for i := 0; i <= 10; i++ {
+			e.Observe(start.Add(time.Duration(i)*time.Second), int64(i*42))
+		}
+		if rate := e.Rate(); rate < 41.9 || rate > 42.1 {
+			t.Errorf("%T.Rate() = %f, want 42", e, rate)
+		}
+	}
+	if got := CustomUnit("items").rate(42); got != "42 items/s" {
+		t.Errorf("rate() = %q, want %q", got, "42 items/s")
+	}
+	if got := UnitBytes.rate(12.3 * 1024 * 1024); got != "12.3 MB/s" {
+		t.Errorf("rate() = %q, want %q", got, "12.3 MB/s")
+	}
+}
+
+func TestProgressReader(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, make([]byte, 3<<20), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	r := n.ProgressReader("copy", f, WithTemplate("{{.Label}} {{.Total}}"))
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(buf.String(), "[✔] copy 3.0 MB\n") {
+		t.Errorf("ProgressReader() output = %q", buf.String())
+	}
+
+	buf.Reset()
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader("hello")), ContentLength: 5}
+	n.ProgressResponse("fetch", resp, WithTemplate("{{.Label}} {{.Percent}} {{.Total}}"))
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if got, want := buf.String(), "[✔] fetch 100% 5 B\n"; got != want {
+		t.Errorf("ProgressResponse() output = %q, want %q", got, want)
+	}
+}
+
+func TestProgressPhases(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	p := New(&buf).Progress("install", 0, WithTemplate("{{.Label}} {{.Percent}}"))
+	download, extract, verify := p.Phase("download", 70), p.Phase("extract", 20), p.Phase("verify", 10)
+
+	download.Set(50)
+	download.Done()
+	extract.Done()
+	verify.Set(50)
+	verify.Done()
+
+	want := "[✔] install › download 35%\n" +
+		"[✔] install › download 70%\n" +
+		"[✔] install › extract 90%\n" +
+		"[✔] install › verify 100%\n"
+	if got := buf.String(); got != want {
+		t.Errorf("phased output = %q, want %q", got, want)
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	RegisterSpinner("bounce", SpinnerStyle{Frames: []string{".", "o", "O"}})
+	if style := spinnerStyle("bounce"); len(style.Frames) != 3 || style.Interval <= 0 {
+		t.Errorf("RegisterSpinner() stored %+v", style)
+	}
+
+	var buf bytes.Buffer
+	n := New(&buf, WithTheme(Theme{Spinner: "bounce", Icons: TaskIcons{Success: "✅"}}))
+	s := n.Spinner("deploying")
+	s.Success("deployed in %ds", 3)
+	s.Fail("ignored after finishing")
+	n.Spinner("checking").Fail("unreachable")
+
+	want := "[✔] deploying…\n[✔] ✅ deployed in 3s\n[✔] checking…\n[✘] ✗ unreachable\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Spinner output = %q, want %q", got, want)
+	}
+}
+
+func TestBurstFolding(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf syncBuffer
+	n := New(&buf, WithBurstFolding(30*time.Millisecond))
+	for i := 1; i <= 5; i++ {
+		n.Warn("retry %d failed", i)
+	}
+	n.Info("unrelated")
+	time.Sleep(80 * time.Millisecond)
+
+	want := "[⚠] retry 1 failed\n[✔] unrelated\n[⚠] retry 1 failed (repeated 4 more times)\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("output after window closed = %q, want %q", got, want)
+	}
+
+	n.Error("fatal")
+	n.Error("fatal")
+	n.Close()
+	want += "[✘] fatal\n[✘] fatal (repeated 1 more time)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output after Close = %q, want %q", got, want)
+	}
+}
+
+func TestEscalate(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Escalate(WarnLevel, 3, time.Minute, CriticalLevel)
+	for i := 0; i < 8; i++ {
+		n.Warn("cache miss for key %d", i)
+	}
+	n.Warn("other")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var summaries []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "[‼]") {
+			summaries = append(summaries, line)
+		}
+	}
+	want := `[‼] "cache miss for key 3" logged 4 times within 1m0s`
+	if len(summaries) != 2 || summaries[0] != want {
+		t.Errorf("Escalate() summaries = %q, want 2 starting with %q", summaries, want)
+	}
+
+	buf.Reset()
+	n = New(&buf)
+	n.Escalate(WarnLevel, 3, 20*time.Millisecond, ErrorLevel)
+	n.Escalate(WarnLevel, 5, 20*time.Millisecond, CriticalLevel)
+	for i := 0; i < 3; i++ {
+		n.Warn("disk slow")
+	}
+	if strings.Contains(buf.String(), "logged") {
+		t.Errorf("Escalate() counted an entry once per rule: %q", buf.String())
+	}
+	n.Warn("once")
+	time.Sleep(30 * time.Millisecond)
+	n.Warn("later")
+	if len(n.seen.times) != 2 {
+		t.Errorf("Escalate() kept %d expired repeats, want only those of the last entry", len(n.seen.times))
+	}
+}
+
+func TestEphemeral(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var console, file bytes.Buffer
+	n := New(&console)
+	n.AddSink(FileSink, &file)
+	n.Ephemeral(InfoLevel, "waiting for lock…")
+	n.Info("lock acquired")
+
+	if got, want := console.String(), "[✔] waiting for lock…\n[✔] lock acquired\n"; got != want {
+		t.Errorf("console output = %q, want %q", got, want)
+	}
+	if got, want := file.String(), "[✔] lock acquired\n"; got != want {
+		t.Errorf("file output = %q, want %q", got, want)
+	}
+
+	// On a terminal the pending line is erased by the next write
+	var tty bytes.Buffer
+	sw := newSwitchWriter(&tty, &sync.Mutex{})
+	sw.live.running = true // Draw by hand instead of on frames
+	sw.live.fleeting = &liveLine{}
+	sw.live.set(sw.live.fleeting, "waiting")
+	sw.live.draw()
+	sw.Write([]byte("next\n"))
+	sw.Write([]byte("after\n"))
+	if got, want := tty.String(), "waiting\n\r\x1b[1A\x1b[Jnext\nafter\n"; got != want {
+		t.Errorf("switchWriter output = %q, want %q", got, want)
+	}
+}
+
+func TestRenderer(t *testing.T) {
+	var tty bytes.Buffer
+	sw := newSwitchWriter(&tty, &sync.Mutex{})
+	sw.live.running = true // Draw by hand instead of on frames
+	var a, b liveLine
+	sw.live.set(&a, "bar a 10%")
+	sw.live.set(&b, "bar b 20%")
+	sw.live.set(&a, "bar a 30%")
+	sw.live.draw()
+	sw.Write([]byte("log line\n"))
+	sw.live.remove(&a)
+	sw.live.draw()
+
+	want := "bar a 30%\nbar b 20%\n" +
+		"\r\x1b[2A\x1b[J" + "log line\n" + "bar a 30%\nbar b 20%\n" +
+		"\r\x1b[2A\x1b[J" + "bar b 20%\n"
+	if got := tty.String(); got != want {
+		t.Errorf("renderer output = %q, want %q", got, want)
+	}
+}
+
+func TestScreen(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	if got, want := Sparkline([]float64{1, 2, 4, 8, math.NaN(), 8}), "▁▂▄█ █"; got != want {
+		t.Errorf("Sparkline() = %q, want %q", got, want)
+	}
+	if got, want := Sparkline([]float64{1, math.Inf(1), math.Inf(-1), 2}), "▁█▁█"; got != want {
+		t.Errorf("Sparkline() with infinities = %q, want %q", got, want)
+	}
+
+	var buf bytes.Buffer
+	s := New(&buf).Screen()
+	s.Draw(s.Status(InfoLevel, "healthy"), "cpu "+Sparkline([]float64{0, 1}))
+	s.Close()
+	s.Draw("ignored")
+
+	if got, want := buf.String(), "[✔] healthy\ncpu ▁█\n"; got != want {
+		t.Errorf("Screen output = %q, want %q", got, want)
+	}
+}
+
+func TestAnimatedInCI(t *testing.T) {
+	for _, name := range ciVariables {
+		t.Setenv(name, "")
+	}
+	if !animated() {
+		t.Error("animated() = false outside CI")
+	}
+	t.Setenv("GITHUB_ACTIONS", "true")
+	if animated() {
+		t.Error("animated() = true on GitHub Actions")
+	}
+
+	SetAnimations(true)
+	defer func() {
+		mu.Lock()
+		animations = nil
+		mu.Unlock()
+	}()
+	if !animated() {
+		t.Error("SetAnimations(true) did not override CI detection")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	for _, name := range []string{"TMUX", "STY", "SSH_TTY", "TERM_PROGRAM", "WT_SESSION", "KITTY_WINDOW_ID", "VTE_VERSION"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("TERM", "screen-256color")
+	t.Setenv("SSH_CONNECTION", "10.0.0.1 22 10.0.0.2 22")
+	t.Setenv("COLUMNS", "120")
+
+	caps := New(&bytes.Buffer{}).Capabilities()
+	if caps.Terminal || caps.Multiplexer != "screen" || !caps.SSH || caps.Width != 120 {
+		t.Errorf("Capabilities() = %+v", caps)
+	}
+	if caps.TrueColor || caps.Hyperlinks || caps.Clipboard {
+		t.Errorf("Capabilities() assumed features on a non-terminal: %+v", caps)
+	}
+
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if got := multiplexer(); got != "tmux" {
+		t.Errorf("multiplexer() = %q, want tmux", got)
+	}
+	t.Setenv("TERM_PROGRAM", "WezTerm")
+	if !hyperlinkTerminal() || !clipboardTerminal() {
+		t.Error("hyperlinkTerminal() or clipboardTerminal() = false for WezTerm")
+	}
+	t.Setenv("TERM_PROGRAM", "Apple_Terminal")
+	if clipboardTerminal() {
+		t.Error("clipboardTerminal() = true for Apple Terminal")
+	}
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("VTE_VERSION", "7600")
+	if clipboardTerminal() {
+		t.Error("clipboardTerminal() = true for a VTE terminal")
+	}
+}
+
+func TestCompactProfile(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+	SetCompactWidth(60)
+	defer SetCompactWidth(0)
+
+	var buf bytes.Buffer
+	n := New(&buf).With("api")
+
+	t.Setenv("COLUMNS", "100")
+	n.Warn("wide")
+	t.Setenv("COLUMNS", "40")
+	n.Warn("narrow")
+	n.Logf(InfoLevel, "stamped")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "[⚠] [api] wide" || lines[1] != "[api] narrow" {
+		t.Fatalf("compact output = %q", lines)
+	}
+	if !regexp.MustCompile(`^\d\d:\d\d:\d\d \[api\] stamped$`).MatchString(lines[2]) {
+		t.Errorf("compact timestamp line = %q", lines[2])
+	}
+	if got := renderTable([]string{"a", "b"}, nil, 40, nil); !strings.HasPrefix(got, "a b\n") {
+		t.Errorf("compact table header = %q", got)
+	}
+}
+
+func TestSessionHooks(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	var exitStats Stats
+	n := New(&buf,
+		OnStart(func(n *Notifier) { n.Notice("deploy v1.2.0") }),
+		OnExit(func(_ *Notifier, s Stats) { exitStats = s }),
+		OnExit(Summary),
+	)
+	n.Warn("slow")
+	n.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "[⚑] deploy v1.2.0" {
+		t.Fatalf("session output = %q", lines)
+	}
+	if !regexp.MustCompile(`^\[✔\] ✓ finished in [0-9.]+m?s: 1 warning, 0 errors$`).MatchString(lines[2]) {
+		t.Errorf("Summary() line = %q", lines[2])
+	}
+	if exitStats.Warnings() != 1 {
+		t.Errorf("OnExit() stats = %+v", exitStats)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.DryRun(true)
+	n.Step("removing %d files", 3)
+	marker := filepath.Join(t.TempDir(), "marker")
+	if err := n.Exec(exec.Command("touch", marker)); err != nil {
+		t.Fatalf("Exec() in dry-run = %v", err)
+	}
+	n.Success("done")
+	n.Info("plain")
+
+	want := "[✔] [dry-run] → removing 3 files\n" +
+		"[✔] [dry-run] $ touch " + marker + "\n" +
+		"[✔] [dry-run] ✓ done\n" +
+		"[✔] plain\n"
+	if got := buf.String(); got != want {
+		t.Errorf("dry-run output = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Exec() ran the command in dry-run mode")
+	}
+
+	buf.Reset()
+	n.DryRun(false)
+	if err := n.Exec(exec.Command("echo", "hi")); err != nil {
+		t.Fatalf("Exec() = %v", err)
+	}
+	if got, want := buf.String(), "[✔] $ echo hi\nhi\n"; got != want {
+		t.Errorf("Exec() output = %q, want %q", got, want)
+	}
+}
+
+func TestDanger(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf, WithInput(strings.NewReader("delete prod\ndelete\n")))
+	if err := n.Danger("This will DELETE 42 resources", ConfirmPhrase("delete prod")); err != nil {
+		t.Fatalf("Danger() with matching phrase = %v", err)
+	}
+	want := "╭─────────────────────────────────╮\n" +
+		"│ ⚠ This will DELETE 42 resources │\n" +
+		"│ Type \"delete prod\" to continue  │\n" +
+		"╰─────────────────────────────────╯\n" +
+		"> "
+	if got := buf.String(); got != want {
+		t.Errorf("Danger() output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := n.Danger("drop table", ConfirmPhrase("delete prod")); !errors.Is(err, ErrNotConfirmed) {
+		t.Errorf("Danger() with wrong phrase = %v, want ErrNotConfirmed", err)
+	}
+	if err := n.Danger("drop table"); err == nil {
+		t.Error("Danger() without input = nil, want read error")
+	}
+
+	buf.Reset()
+	if err := n.Danger("drop table", AssumeConfirmed(true)); err != nil {
+		t.Errorf("Danger() assumed = %v", err)
+	}
+	if got := buf.String(); got != "[⚠] drop table (confirmation skipped)\n" {
+		t.Errorf("Danger() assumed output = %q", got)
+	}
+}
+
+func TestMenu(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf, WithInput(strings.NewReader("9\n2\n")))
+	var picked string
+	err := n.Menu("Admin", []MenuItem{
+		{Label: "Restart", Action: func() error { picked = "restart"; return nil }},
+		{Label: "Purge cache", Action: func() error { picked = "purge"; return errors.New("cache locked") }},
+	})
+	if err == nil || picked != "purge" {
+		t.Fatalf("Menu() = %v, picked %q", err, picked)
+	}
+	want := "Admin\n  1) Restart\n  2) Purge cache\n" +
+		"Choose 1-2: [⚠] invalid choice \"9\"\n" +
+		"Choose 1-2: [✘] ✗ Purge cache: cache locked\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Menu() output = %q, want %q", got, want)
+	}
+	if err := n.Menu("Empty", nil); !errors.Is(err, ErrNoMenuItems) {
+		t.Errorf("Menu() without items = %v, want ErrNoMenuItems", err)
+	}
+}
+
+func TestForm(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var cfg struct {
+		Name    string        `form:"Project name,required" pattern:"^[a-z]+$"`
+		Port    int           `default:"8080"`
+		Timeout time.Duration `default:"5s"`
+		Token   string        `form:",masked"`
+		Skipped string        `form:"-"`
+	}
+	var buf bytes.Buffer
+	n := New(&buf, WithInput(strings.NewReader("\nMy App\nshop\nhttp\n\n\ns3cr3t\n")))
+	if err := n.Form(&cfg); err != nil {
+		t.Fatalf("Form() = %v", err)
+	}
+	if cfg.Name != "shop" || cfg.Port != 8080 || cfg.Timeout != 5*time.Second || cfg.Token != "s3cr3t" {
+		t.Errorf("Form() filled %+v", cfg)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"[⚠] Project name is required\n",
+		"[⚠] Project name must match ^[a-z]+$\n",
+		"Port [8080]: [⚠] Port: strconv.ParseInt",
+		"Project name  shop\nPort          8080\nTimeout       5s\nToken         ••••••\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Form() output missing %q in %q", want, out)
+		}
+	}
+
+	if err := n.Form(cfg); err == nil {
+		t.Error("Form() with a non-pointer = nil, want error")
+	}
+}
+
+func TestCopyable(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	New(&buf).Copyable("API token", "tok_123")
+	if got, want := buf.String(), "[✔] API token:\n  tok_123\n"; got != want {
+		t.Errorf("Copyable() = %q, want %q", got, want)
+	}
+	if got, want := osc52("hi"), "\x1b]52;c;aGk=\a"; got != want {
+		t.Errorf("osc52() = %q, want %q", got, want)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	New(&buf).Command("kubectl rollout undo deploy/api")
+	if got, want := buf.String(), "   $ kubectl rollout undo deploy/api \n"; got != want {
+		t.Errorf("Command() = %q, want %q", got, want)
+	}
+}
+
+func TestCurl(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.example.com/v1/items?id=7&x=1", strings.NewReader(`{"name":"it's"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+
+	var buf bytes.Buffer
+	New(&buf).Curl(req)
+	want := `   $ curl -X POST 'https://api.example.com/v1/items?id=7&x=1' -H 'Authorization: [REDACTED]' ` +
+		`-H 'Content-Type: application/json' --data-raw '{"name":"it'\''s"}' ` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Curl() = %q, want %q", got, want)
+	}
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"name":"it's"}` {
+		t.Errorf("Curl() consumed the body, left %q", body)
+	}
+
+	get, _ := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	get.Header.Set("X-Token", "abc")
+	if got, want := New(io.Discard, WithRedactedHeaders("x-token")).curlCommand(get), "curl https://example.com/ -H 'X-Token: [REDACTED]'"; got != want {
+		t.Errorf("curlCommand() = %q, want %q", got, want)
+	}
+
+	server := httptest.NewRequest(http.MethodPut, "/v1/blobs/7", strings.NewReader(strings.Repeat("x", maxCurlBody+10)))
+	server.Host = "files.example.com"
+	cmd := New(io.Discard).curlCommand(server)
+	if !strings.HasPrefix(cmd, "curl -X PUT http://files.example.com/v1/blobs/7 --data-raw ") ||
+		!strings.HasSuffix(cmd, "x # body truncated at 64.0 KB") || len(cmd) > maxCurlBody+200 {
+		t.Errorf("curlCommand() of a server request = %q…%q", cmd[:80], cmd[len(cmd)-40:])
+	}
+	if body, _ := io.ReadAll(server.Body); len(body) != maxCurlBody+10 {
+		t.Errorf("Curl() left %d body bytes, want %d", len(body), maxCurlBody+10)
+	}
+}
+
+func TestHTTPValues(t *testing.T) {
+	for code, want := range map[int]color.Attribute{200: color.FgGreen, 304: color.FgCyan, 404: color.FgYellow, 503: color.FgRed} {
+		if got := Status(code); got.value != strconv.Itoa(code) || len(got.attrs) != 1 || got.attrs[0] != want {
+			t.Errorf("Status(%d) = %+v", code, got)
+		}
+	}
+	if got := Status(101); len(got.attrs) != 0 {
+		t.Errorf("Status(101) = %+v, want plain", got)
+	}
+	if got := Method("delete"); len(got.attrs) != 1 || got.attrs[0] != color.FgRed || got.value != "delete" {
+		t.Errorf("Method(delete) = %+v", got)
+	}
+	if got := Method("PURGE"); len(got.attrs) != 0 {
+		t.Errorf("Method(PURGE) = %+v, want plain", got)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Latency("db.query", 120*time.Millisecond, 100*time.Millisecond)
+	n.Latency("db.query", 40*time.Millisecond, 100*time.Millisecond)
+	n.Latency("cache", time.Millisecond, 5*time.Millisecond)
+	n.Close()
+
+	want := "[⚠] [▲ 120%] db.query 120ms (budget 100ms)\n" +
+		"[✔] [▼ 40%] db.query 40ms (budget 100ms)\n" +
+		"[✔] [▼ 20%] cache 1ms (budget 5ms)\n" +
+		"[⚠] latency db.query: 1/2 within 100ms budget, max 120ms\n" +
+		"[⚑] latency cache: 1/1 within 5ms budget, max 1ms\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Latency() output = %q, want %q", got, want)
+	}
+}
+
+func TestCounterGauge(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+	SetMetricInterval(0)
+	defer SetMetricInterval(10 * time.Second)
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	processed, depth := n.Counter("processed"), n.Gauge("queue depth")
+	processed.Inc()
+	processed.Add(2)
+	processed.Add(-5)
+	depth.Set(4.5)
+	if processed.Value() != 3 || depth.Value() != 4.5 {
+		t.Errorf("Value() = %d, %g", processed.Value(), depth.Value())
+	}
+	if got := n.Stats().Metrics; got["processed"] != 3 || got["queue depth"] != 4.5 {
+		t.Errorf("Stats().Metrics = %v", got)
+	}
+	n.Close()
+
+	want := "[✔] processed: 3, queue depth: 0\n" +
+		"[✔] processed: 3, queue depth: 4.5\n" +
+		"[⚑] processed: 3, queue depth: 4.5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("metrics output = %q, want %q", got, want)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.SetRateLimit(2, 0)
+	for i := 1; i <= 5; i++ {
+		n.Info("line %d", i)
+	}
+	n.Close()
+	want := "[✔] line 1\n[✔] line 2\n… 3 lines dropped by the output rate limit\n"
+	if got := buf.String(); got != want {
+		t.Errorf("rate limited output = %q, want %q", got, want)
+	}
+
+	r := &rateLimit{bytes: 10}
+	start := time.Now()
+	if ok, _ := r.allow([]byte("0123456789x"), start); ok {
+		t.Error("allow() accepted a write over the byte cap")
+	}
+	if ok, notice := r.allow([]byte("ok\n"), start.Add(time.Second)); !ok || notice != "… 1 line dropped by the output rate limit\n" {
+		t.Errorf("allow() in the next second = %v, %q", ok, notice)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use in tests
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// blockingWriter blocks every write until release is closed
+type blockingWriter struct {
+	release chan struct{}
+	buf     syncBuffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return w.buf.Write(p)
+}
+
+func TestWriteTimeout(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	w := &blockingWriter{release: make(chan struct{})}
+	n := New(w)
+	n.SetWriteTimeout(10 * time.Millisecond)
+	defer n.SetWriteTimeout(0)
+
+	start := time.Now()
+	n.Info("stuck")
+	n.Info("dropped 1")
+	n.Info("dropped 2")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("writes blocked for %s", elapsed)
+	}
+	if got := n.Stats().Stalled; got != 2 {
+		t.Errorf("Stats().Stalled = %d, want 2", got)
+	}
+
+	close(w.release)
+	time.Sleep(20 * time.Millisecond)
+	n.Info("recovered")
+	if got, want := w.buf.String(), "[✔] stuck\n[✔] recovered\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf, WithLevel(InfoLevel))
+	calls := 0
+	expensive := Lazy(func() any { calls++; return 3.14159 })
+
+	n.Debug("skipped %v", expensive)
+	if calls != 0 || n.Enabled(DebugLevel) {
+		t.Errorf("filtered entry evaluated Lazy %d times, Enabled(Debug) = %v", calls, n.Enabled(DebugLevel))
+	}
+	n.Info("pi %.2f", expensive)
+	if calls != 1 || buf.String() != "[✔] pi 3.14\n" {
+		t.Errorf("Info() = %q after %d calls", buf.String(), calls)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { n.Enabled(DebugLevel) }); allocs != 0 {
+		t.Errorf("Enabled() allocates %v times", allocs)
+	}
+}
+
+func TestAsync(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var out syncBuffer
+	n := New(&out)
+	n.SetAsync(16)
+	defer n.SetAsync(0)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			child := n.With(fmt.Sprint("g", g))
+			for i := 0; i < 50; i++ {
+				child.Info("line %d", i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	n.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 200 {
+		t.Fatalf("async wrote %d lines, want 200", len(lines))
+	}
+	line := regexp.MustCompile(`^\[✔\] \[g\d\] line \d+$`)
+	for _, l := range lines {
+		if !line.MatchString(l) {
+			t.Fatalf("async line %q is torn", l)
+		}
+	}
+
+	n.SetAsync(0)
+	n.Info("sync again")
+	if !strings.HasSuffix(out.String(), "[✔] sync again\n") {
+		t.Errorf("SetAsync(0) left output queued: %q", out.String())
+	}
+
+	// A Flush that fetched the writer just before SetAsync replaced it
+	a := newAsyncWriter(io.Discard, 1)
+	a.stop()
+	a.flush()
+	a.Write([]byte("late\n"))
+}
+
+func TestCoarseTime(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf, WithCoarseTime(time.Hour), WithTimeFormat(time.RFC3339Nano))
+	n.Logf(InfoLevel, "first")
+	time.Sleep(2 * time.Millisecond)
+	n.Logf(InfoLevel, "second")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || strings.Fields(lines[0])[1] != strings.Fields(lines[1])[1] {
+		t.Errorf("entries within one tick got different timestamps: %q", lines)
+	}
+
+	first := n.output.clock.Load()
+	child := n.WithOptions(WithCoarseTime(time.Hour))
+	if child.output.clock.Load() == first || first.now.Load() != nil {
+		t.Error("WithCoarseTime() on a derived Notifier left the previous clock running")
+	}
+
+	frozen := n.now()
+	n.Close()
+	if got := n.now(); !got.After(frozen) {
+		t.Errorf("now() after Close = %v, still the coarse %v", got, frozen)
+	}
+	if child.output.clock.Load() != nil {
+		t.Error("Close() left the clock of a derived Notifier running")
+	}
+}
+
+func TestEntry(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var got []Entry
+	n := New(io.Discard)
+	n.AddEntrySink(FileSink, func(e Entry) { got = append(got, e) })
+	n.With("api").Warn("disk %d%% full", 91)
+	n.At(InfoLevel).Only(ConsoleSink).Msg("console only")
+	if len(got) != 1 {
+		t.Fatalf("entry sink got %d entries, want 1", len(got))
+	}
+
+	e := got[0]
+	e.Time = time.Date(2025, 3, 25, 13, 23, 45, 0, time.UTC)
+	if e.Level != WarnLevel || e.Prefix != "api" || e.Message != "disk 91% full" {
+		t.Errorf("Entry = %+v", e)
+	}
+	if text, _ := e.MarshalText(); string(text) != "2025-03-25T13:23:45Z [WARN] [api] disk 91% full" {
+		t.Errorf("MarshalText() = %q", text)
+	}
+	data, _ := json.Marshal(e)
+	if string(data) != `{"time":"2025-03-25T13:23:45Z","level":"warn","prefix":"api","msg":"disk 91% full"}` {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+	if m := e.ToMap(); m["prefix"] != "api" || m["level"] != "warn" || len(m) != 4 {
+		t.Errorf("ToMap() = %v", m)
+	}
+	r := e.ToSlogRecord()
+	if r.Level != slog.LevelWarn || r.Message != "disk 91% full" || r.NumAttrs() != 1 {
+		t.Errorf("ToSlogRecord() = %+v", r)
+	}
+}
+
+func TestUse(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Use(Redact(`token=\w+`, "token=***"), func(e Entry) []Entry {
+		switch {
+		case strings.Contains(e.Message, "noise"):
+			return nil
+		case e.Level == ErrorLevel:
+			audit := e
+			audit.Prefix, audit.Level = "audit", NoticeLevel
+			return []Entry{e, audit}
+		}
+		return []Entry{e}
+	})
+	child := n.With("api")
+	n.Use(func(e Entry) []Entry { e.Message += "!"; return []Entry{e} })
+
+	n.Info("login token=abc123")
+	n.Info("noise")
+	n.Error("denied")
+	child.Warn("slow")
+
+	want := "[✔] login token=***!\n" +
+		"[✘] denied!\n" +
+		"[⚑] [audit] denied!\n" +
+		"[⚠] [api] slow\n"
+	if buf.String() != want {
+		t.Errorf("Use() output = %q, want %q", buf.String(), want)
+	}
+	if s := n.Stats(); s.Dropped != 1 {
+		t.Errorf("Stats().Dropped = %d, want 1", s.Dropped)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	Check() // Drop reports left by other tests
+	n := New(io.Discard)
+	n.Info("%d%% done", 50)
+	n.Info("%s and %s", "one")
+	n.AddSink(FileSink, nil)
+	New(io.Discard, OnExit(Summary)).Close()
+	n.Close()
+	n.Info("late")
+	err := Check()
+	if err == nil {
+		t.Fatal("Check() = nil, want misuse")
+	}
+	for _, want := range []string{`"%s and %s"`, "AddSink with a nil writer", `"late" logged after Close`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Check() = %q, want it to mention %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "50") || strings.Count(err.Error(), "after Close") != 1 {
+		t.Errorf("Check() = %q, reports correct use", err)
+	}
+	if err := Check(); err != nil {
+		t.Errorf("second Check() = %v, want nil", err)
+	}
+
+	SetStrict(true)
+	defer SetStrict(false)
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "EXTRA") {
+			t.Errorf("strict mode recovered %v, want a panic on the extra argument", r)
+		}
+	}()
+	New(io.Discard).Warn("disk full", "/var")
+}
+
+func TestVetFormats(t *testing.T) {
+	err := VetFormats(filepath.Join("testdata", "vet"))
+	if err == nil {
+		t.Fatal("VetFormats() = nil, want mismatches")
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 3 ||
+		!strings.HasSuffix(lines[0], `main.go:15:2: Info format "%s and %s" expects 2 args, got 1`) ||
+		!strings.HasSuffix(lines[1], `main.go:17:2: Warn format "%v" expects 1 arg, got 2`) ||
+		!strings.HasSuffix(lines[2], `main.go:23:2: Success format "done %d" expects 1 arg, got 0`) {
+		t.Errorf("VetFormats() = %q", err)
+	}
+	if err := VetFormats("."); err != nil {
+		t.Errorf("VetFormats(.) = %v", err)
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	child := n.With("api").WithOptions(WithLevel(DebugLevel)).With("v2")
+	if got := child.Prefixes(); !slices.Equal(got, []string{"api", "v2"}) {
+		t.Errorf("Prefixes() = %q", got)
+	}
+	if child.Root() != n || n.Root() != n {
+		t.Error("Root() does not return the Notifier created by New")
+	}
+	if clone := child.Clone(io.Discard); clone.Root() != clone || len(clone.Prefixes()) != 2 {
+		t.Error("Clone() keeps the prefixes but must be its own root")
+	}
+
+	replaced := child.WithOptions(WithReplacePrefix("db"))
+	replaced.Info("connected")
+	replaced.With("pool").Info("ready")
+	child.WithOptions(WithReplacePrefix("")).Info("bare")
+	child.Info("still nested")
+	want := "[✔] [db] connected\n" +
+		"[✔] [db pool] ready\n" +
+		"[✔] bare\n" +
+		"[✔] [api v2] still nested\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	service := New(&buf).WithFields(Fields{"service": "api", "version": "1.2"})
+	request := service.WithFields(Fields{"version": "2.0", "user": "ann marie", "id": 7})
+	request.Info("login")
+	request.Without("version", "service").Warn("slow")
+	service.Info("idle")
+
+	want := `[✔] login service=api version=2.0 id=7 user="ann marie"` + "\n" +
+		`[⚠] slow id=7 user="ann marie"` + "\n" +
+		"[✔] idle service=api version=1.2\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	service.WithOptions(WithJSONFormat()).Info("idle")
+	if !strings.Contains(buf.String(), `"fields":{"service":"api","version":"1.2"}`) {
+		t.Errorf("JSON output = %q, want nested fields", buf.String())
+	}
+
+	buf.Reset()
+	odd := New(&buf).WithFields(Fields{"ratio": math.NaN(), "trace": "a\nb"})
+	odd.Info("text")
+	odd.WithOptions(WithJSONFormat()).Info("json")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != `[✔] text ratio=NaN trace="a\nb"` {
+		t.Fatalf("output = %q, want one quoted line and a JSON line", buf.String())
+	}
+	if !strings.Contains(lines[1], `"msg":"json","fields":{"ratio":"NaN","trace":"a\nb"}`) {
+		t.Errorf("JSON output = %q, want unencodable values as text", lines[1])
+	}
+}
+
+func TestEventStyle(t *testing.T) {
+	var buf bytes.Buffer
+	n := New(&buf, WithColorMode(ColorAlways)).With("deploy")
+	n.At(InfoLevel).Style(NewStyle(color.FgHiBlue)).Msg("shipped %s", "v2")
+	n.Info("routine")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("output = %q, want 2 lines", buf.String())
+	}
+	if !strings.Contains(lines[0], "\x1b[94m") || strings.Contains(lines[0], "\x1b[92m") {
+		t.Errorf("styled entry = %q, want blue instead of the Info green", lines[0])
+	}
+	if StripANSI(lines[0]) != "[✔] [deploy] shipped v2" {
+		t.Errorf("styled entry = %q, want symbol and prefix kept", StripANSI(lines[0]))
+	}
+	if !strings.Contains(lines[1], "\x1b[92m") {
+		t.Errorf("next entry = %q, want the Info green back", lines[1])
+	}
+}
+
+func TestSymbolPosition(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf, WithTimeFormat("15:04"), WithSymbol(WarnLevel, "🔥"), WithSymbolWidth(3))
+	n.Info("narrow")
+	n.Warn("wide")
+	after := n.WithOptions(WithSymbolPosition(SymbolAfterTime))
+	after.Logf(InfoLevel, "stamped")
+	hidden := n.WithOptions(WithSymbolPosition(SymbolHidden)).With("api")
+	hidden.Info("no symbol")
+	hidden.Logf(InfoLevel, "only time")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("output = %q, want 5 lines", buf.String())
+	}
+	if lines[0] != "[✔] narrow" || lines[1] != "🔥  wide" {
+		t.Errorf("padded symbols = %q, %q", lines[0], lines[1])
+	}
+	if !regexp.MustCompile(`^\d\d:\d\d \[✔\] stamped$`).MatchString(lines[2]) {
+		t.Errorf("SymbolAfterTime = %q", lines[2])
+	}
+	if lines[3] != "[api] no symbol" || !regexp.MustCompile(`^\d\d:\d\d \[api\] only time$`).MatchString(lines[4]) {
+		t.Errorf("SymbolHidden = %q, %q", lines[3], lines[4])
+	}
+}
+
+func TestWithMinimal(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf, WithMinimal())
+	n.Info("fetched")
+	n.Logf(WarnLevel, "cache stale")
+	n.With("db").Error("down")
+
+	want := "· fetched\n! cache stale\n✗ [db] down\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCodef(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Codef("restart with %s", Code("systemctl restart api"))
+	n.Codef("then run [code]make %s[/code] and [code]make test[/code]", "build")
+	color.NoColor = false
+	n.Codef("see %s", Code("/etc/api.conf"))
+
+	want := "[✔] restart with `systemctl restart api`\n" +
+		"[✔] then run `make build` and `make test`\n"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("output = %q, want prefix %q", buf.String(), want)
+	}
+	if !strings.Contains(buf.String(), "\x1b[100;97m /etc/api.conf ") {
+		t.Errorf("colored span missing in %q", buf.String())
+	}
+
+	buf.Reset()
+	New(&buf, WithColorMode(ColorNever)).Codef("run %s", Code("make"))
+	color.NoColor = true
+	New(&buf, WithColorMode(ColorAlways)).Codef("run [code]make[/code]")
+	if got := StripANSI(buf.String()); got != "[✔] run `make`\n[✔] run  make \n" || !strings.Contains(buf.String(), "\x1b[100;97m make ") {
+		t.Errorf("spans ignore the color mode: %q", buf.String())
+	}
+}
+
+func TestNotifierQuote(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	New(&buf).Blockquote("HTTP 502 Bad Gateway\n\nupstream timed out\n")
+	want := "┃ HTTP 502 Bad Gateway\n┃\n┃ upstream timed out\n"
+	if buf.String() != want {
+		t.Errorf("Blockquote() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	path := filepath.Join(t.TempDir(), "app.toml")
+	src := "[server]\nport = 80\n\thost = \"localhost\n[db]\nname = \"app\"\n"
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	n := New(&buf)
+	if err := n.Excerpt(path, 3, 8, 1); err != nil {
+		t.Fatal(err)
+	}
+	want := "  --> " + path + ":3:8\n" +
+		"2 │ port = 80\n" +
+		"3 │ \thost = \"localhost\n" +
+		"  │ \t      ^\n" +
+		"4 │ [db]\n"
+	if buf.String() != want {
+		t.Errorf("Excerpt() = %q, want %q", buf.String(), want)
+	}
+	if err := n.Excerpt(filepath.Join(t.TempDir(), "missing"), 1, 1, 1); err == nil {
+		t.Error("Excerpt() of a missing file returned nil")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	path := filepath.Join(t.TempDir(), "app.toml")
+	if err := os.WriteFile(path, []byte("[server]\nport = \"80\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var bag DiagnosticBag
+	port := Diagnostic{Severity: ErrorLevel, Message: "port must be a number", File: path, Line: 2, Col: 8,
+		Rule: "type", Notes: []string{"ports are integers"}, Suggestions: []string{`port = 80`}}
+	bag.Add(Diagnostic{Severity: WarnLevel, Message: "missing [db] section", File: path})
+	bag.Add(port)
+	bag.Add(port)
+	bag.Add(Diagnostic{Severity: WarnLevel, Message: "deprecated key", File: path, Line: 2, Col: 1})
+
+	if got := len(bag.Diagnostics()); got != 3 {
+		t.Errorf("Diagnostics() returned %d entries, want 3 without duplicates", got)
+	}
+	var buf bytes.Buffer
+	New(&buf).Diagnostics(&bag)
+	want := "[⚠] " + path + ": missing [db] section\n" +
+		"[⚠] " + path + ":2:1: deprecated key\n" +
+		"1 │ [server]\n" +
+		"2 │ port = \"80\"\n" +
+		"  │ ^\n" +
+		"[✘] " + path + ":2:8: port must be a number [type]\n" +
+		"1 │ [server]\n" +
+		"2 │ port = \"80\"\n" +
+		"  │        ^\n" +
+		"  = note: ports are integers\n" +
+		"  = help: port = 80\n" +
+		"[✘] 2 warnings, 1 error\n"
+	if buf.String() != want {
+		t.Errorf("Diagnostics() output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	New(&buf).Diagnostics(&DiagnosticBag{})
+	if buf.String() != "[✔] no problems found\n" {
+		t.Errorf("empty bag output = %q", buf.String())
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var bag DiagnosticBag
+	bag.Add(Diagnostic{Severity: ErrorLevel, Message: "port must be a number", File: "conf/app.toml", Line: 2, Col: 8,
+		Rule: "type", Suggestions: []string{"port = 80"}})
+	bag.Add(Diagnostic{Severity: WarnLevel, Message: "missing [db] section", File: "conf/app.toml", Rule: "type"})
+	bag.Add(Diagnostic{Severity: NoticeLevel, Message: "no config for staging"})
+
+	var buf bytes.Buffer
+	if err := bag.WriteSARIF(&buf, "confcheck"); err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string           `json:"name"`
+					Rules []map[string]any `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Message   struct{ Text string }
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct{ URI string }
+						Region           *struct{ StartLine, StartColumn int }
+					}
+				}
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIF() wrote invalid JSON: %v", err)
+	}
+	run := log.Runs[0]
+	if log.Version != "2.1.0" || run.Tool.Driver.Name != "confcheck" || len(run.Tool.Driver.Rules) != 1 {
+		t.Errorf("SARIF header = %s", buf.String())
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("SARIF results = %d, want 3", len(run.Results))
+	}
+	first, second, third := run.Results[0], run.Results[1], run.Results[2]
+	if third.Level != "error" || third.Message.Text != "port must be a number\nport = 80" ||
+		third.Locations[0].PhysicalLocation.Region.StartColumn != 8 {
+		t.Errorf("error result = %+v", third)
+	}
+	if second.Level != "warning" || second.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("warning result = %+v", second)
+	}
+	if first.Level != "note" || len(first.Locations) != 0 {
+		t.Errorf("note result = %+v", first)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	n := New(io.Discard)
+	n.Spinner("compile").Success("compiled")
+	n.Spinner("lint").Fail("3 issues")
+	n.Spinner("deploy").Stop()
+	n.Spinner("migrate").Fail("")
+	n.Spinner("cache").Success("")
+	n.With("ci").Record("upload", 1500*time.Millisecond, nil)
+
+	var buf bytes.Buffer
+	if err := n.WriteJUnit(&buf, "build"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<testsuites tests="6" failures="2" skipped="1"`,
+		`<testsuite name="build" tests="6" failures="2" skipped="1"`,
+		`<testcase name="compile" classname="build"`,
+		`<failure message="3 issues">3 issues</failure>`,
+		`<skipped></skipped>`,
+		`<testcase name="upload" classname="build" time="1.500">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteJUnit() = %s\nwant it to contain %s", out, want)
+		}
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Errorf("WriteJUnit() wrote invalid XML: %v", err)
+	}
+}
+
+func TestSetEvents(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var human, events bytes.Buffer
+	n := New(&human)
+	n.SetEvents(&events)
+	n.With("api").Warn("slow")
+	n.Spinner("lint").Fail("3 issues")
+	p := n.Progress("copy", 4)
+	p.Add(2)
+	p.Add(2)
+	p.Done()
+	n.SetEvents(nil)
+	n.Info("not streamed")
+
+	var kinds []string
+	dec := json.NewDecoder(&events)
+	for dec.More() {
+		var ev map[string]any
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatal(err)
+		}
+		kind := ev["type"].(string)
+		switch kind {
+		case "log":
+			kind += ":" + ev["msg"].(string)
+		case "result":
+			kind += ":" + ev["status"].(string)
+		case "progress":
+			kind += ":" + strconv.FormatFloat(ev["current"].(float64), 'f', 0, 64)
+		}
+		if !strings.HasPrefix(kind, "log:copy") {
+			kinds = append(kinds, kind)
+		}
+	}
+	want := []string{"log:slow", "start", "log:lint…", "result:fail", "log:✗ 3 issues", "progress:2", "progress:4"}
+	if !slices.Equal(kinds, want) {
+		t.Errorf("event kinds = %q, want like %q", kinds, want)
+	}
+	if strings.Contains(human.String(), "{") {
+		t.Errorf("human output carries events: %q", human.String())
+	}
+}
+
+func TestPlan(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var cs ChangeSet
+	cs.Add("aws_instance.web", "t3.micro")
+	cs.Change("aws_s3_bucket.logs", "versioning: off → on")
+	cs.Delete("aws_iam_role.old", "")
+	cs.Add("dns.www", "")
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Plan(&cs)
+	want := "  + aws_instance.web    t3.micro\n" +
+		"  ~ aws_s3_bucket.logs  versioning: off → on\n" +
+		"  - aws_iam_role.old\n" +
+		"  + dns.www\n" +
+		"\nPlan: 2 to add, 1 to change, 1 to destroy.\n"
+	if buf.String() != want {
+		t.Errorf("Plan() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	n.Plan(&ChangeSet{})
+	if buf.String() != "No changes.\n" {
+		t.Errorf("empty Plan() = %q", buf.String())
+	}
+}
+
+func TestFileStatus(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.FileStatus([]FileState{
+		{Path: "main.go", Change: FileModified},
+		{Path: "new.go", Change: FileAdded},
+		{Path: "b.go", From: "a.go", Change: FileRenamed},
+		{Path: "notes.txt", Change: FileUntracked},
+	})
+	want := "M main.go\nA new.go\nR a.go → b.go\n? notes.txt\n"
+	if buf.String() != want {
+		t.Errorf("FileStatus() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	n.FileStatus([]FileState{
+		{Path: "main.go", Staged: FileModified, Change: FileModified},
+		{Path: "old.go", Staged: FileDeleted},
+		{Path: "go.sum", Change: FileModified},
+		{Path: "tmp/", Change: FileUntracked},
+	})
+	want = "MM main.go\nD  old.go\n M go.sum\n?? tmp/\n"
+	if buf.String() != want {
+		t.Errorf("FileStatus() with staging = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+	t.Setenv("COLUMNS", "40")
+
+	start := time.Date(2025, 3, 25, 13, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Timeline([]Span{
+		{Name: "fetch", Start: start, Duration: 2 * time.Second},
+		{Name: "build", Start: start.Add(2 * time.Second), Duration: 4 * time.Second},
+		{Name: "test", Start: start.Add(3 * time.Second), Duration: 5 * time.Second},
+	})
+	want := "fetch  ███████                        2s\n" +
+		"build         ██████████████          4s\n" +
+		"test             ███████████████████  5s\n" +
+		"       0s           4s            8s\n"
+	if buf.String() != want {
+		t.Errorf("Timeline() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	n.Record("upload", time.Second, nil)
+	if spans := n.Spans(); len(spans) != 1 || spans[0].Name != "upload" || spans[0].Duration != time.Second {
+		t.Errorf("Spans() = %+v", spans)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	New(&buf).Graph([]string{"app", "http", "db", "log"}, []Edge{
+		{"app", "http"}, {"app", "db"}, {"http", "log"}, {"db", "log"}, {"db", "pool"},
+		{"pool", "db"}, {"x", "y"}, {"y", "x"},
+	})
+	want := "app\n" +
+		"├── http\n" +
+		"│   └── log\n" +
+		"└── db\n" +
+		"    ├── log (*)\n" +
+		"    └── pool\n" +
+		"        └── db ↺ cycle\n" +
+		"x\n" +
+		"└── y\n" +
+		"    └── x ↺ cycle\n"
+	if buf.String() != want {
+		t.Errorf("Graph() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 15, 0, 0, 0, time.Local) }
+	var buf bytes.Buffer
+	New(&buf).Heatmap(map[time.Time]int{
+		day(time.March, 25):               1,
+		day(time.March, 27):               8,
+		day(time.April, 2):                4,
+		day(time.April, 8):                2,
+		day(time.April, 8).Add(time.Hour): 2,
+	})
+	want := "    Mar Apr\n" +
+		"    · · · \n" +
+		"Mon · · · \n" +
+		"    ░ · ▒ \n" +
+		"Wed · ▒ \n" +
+		"    █ · \n" +
+		"Fri · · \n" +
+		"    · · \n" +
+		"    Less · ░ ▒ ▓ █ More\n"
+	if buf.String() != want {
+		t.Errorf("Heatmap() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestMeters(t *testing.T) {
+	var buf bytes.Buffer
+	n := New(&buf, WithColorMode(ColorAlways))
+	n.Meters([]Reading{
+		{Label: "memory", Value: 3.5, Max: 16},
+		{Label: "disk", Value: 75, Max: 100},
+		{Label: "quota", Value: 120, Max: 100},
+	})
+	n.Meter("empty", 0, 0)
+	n.Meter("nan", math.NaN(), 100)
+	n.Meter("inf", math.Inf(1), math.Inf(1))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []string{
+		"memory  ████░░░░░░░░░░░░░░░░  22% 3.5/16",
+		"disk    ███████████████░░░░░  75% 75/100",
+		"quota   ████████████████████ 100% 120/100",
+		"empty  ░░░░░░░░░░░░░░░░░░░░   0% 0/0",
+		"nan  ░░░░░░░░░░░░░░░░░░░░   0% NaN/100",
+		"inf  ░░░░░░░░░░░░░░░░░░░░   0% +Inf/+Inf",
+	}
+	for i, line := range lines {
+		if StripANSI(line) != want[i] {
+			t.Errorf("line %d = %q, want %q", i, StripANSI(line), want[i])
+		}
+	}
+	for i, code := range []string{"\x1b[32m", "\x1b[33m", "\x1b[31m"} {
+		if !strings.HasPrefix(lines[i][8:], code) {
+			t.Errorf("line %d = %q, want color %q", i, lines[i], code)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var buf bytes.Buffer
+	n := New(&buf, WithColorMode(ColorAlways))
+	n.Compare([]string{"std", "fast", "faster"}, [][]float64{
+		{120, 80, 95},
+		{3, 3, 3},
+		{10.5, math.NaN(), 7},
+	}, false)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Compare() = %q, want 5 lines", buf.String())
+	}
+	green, red := "\x1b[32;1m", "\x1b[31m"
+	if !strings.Contains(lines[2], red+"120") || !strings.Contains(lines[2], green+"80") || strings.Contains(lines[2], "m95") {
+		t.Errorf("row 1 = %q, want 80 best and 120 worst", lines[2])
+	}
+	if strings.Contains(lines[3], "\x1b[") {
+		t.Errorf("row 2 = %q, want equal values plain", lines[3])
+	}
+	if !strings.Contains(lines[4], red+"10.5") || !strings.Contains(lines[4], green+"7") {
+		t.Errorf("row 3 = %q, want NaN skipped", lines[4])
+	}
+
+	buf.Reset()
+	n.Compare([]string{"a", "b"}, [][]float64{{1, 2}}, true)
+	if !strings.Contains(buf.String(), green+"2") || !strings.Contains(buf.String(), red+"1") {
+		t.Errorf("higherIsBetter output = %q", buf.String())
+	}
+}
+
+func TestDiff(t *testing.T) {
+	var buf bytes.Buffer
+	n := New(&buf, WithColorMode(ColorAlways))
+	before := "a\nb\nc\nd\nport = 8080\nf\ng\nh\ni\nj\nk\nl\n"
+	after := "a\nb\nc\nd\nport = 9090\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	n.Diff(before, after)
+
+	want := []string{
+		"@@ -2,7 +2,7 @@", "  b", "  c", "  d", "- port = 8080", "+ port = 9090", "  f", "  g", "  h",
+		"@@ -10,3 +10,4 @@", "  j", "  k", "  l", "+ m",
+	}
+	lines := strings.Split(strings.TrimSuffix(StripANSI(buf.String()), "\n"), "\n")
+	if !slices.Equal(lines, want) {
+		t.Fatalf("Diff() = %q, want %q", lines, want)
+	}
+	if !strings.Contains(buf.String(), "\x1b[41;97m8080") || !strings.Contains(buf.String(), "\x1b[42;30m9090") {
+		t.Errorf("Diff() = %q, want the changed word highlighted", buf.String())
+	}
+	if strings.Contains(buf.String(), "\x1b[41;97mport") {
+		t.Errorf("Diff() = %q, want unchanged words without background", buf.String())
+	}
+
+	buf.Reset()
+	n.Diff("same\n", "same\n")
+	if got := StripANSI(buf.String()); got != "no differences\n" {
+		t.Errorf("Diff(equal) = %q", got)
+	}
+}
+
+func TestDiffTokens(t *testing.T) {
+	lcs := func(a, b []string) int {
+		prev := make([]int, len(b)+1)
+		for i := range a {
+			cur := make([]int, len(b)+1)
+			for j := range b {
+				if a[i] == b[j] {
+					cur[j+1] = prev[j] + 1
+				} else {
+					cur[j+1] = max(prev[j+1], cur[j])
+				}
+			}
+			prev = cur
+		}
+		return prev[len(b)]
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		a, b := make([]string, r.Intn(12)), make([]string, r.Intn(12))
+		for k := range a {
+			a[k] = string(rune('a' + r.Intn(4)))
+		}
+		for k := range b {
+			b[k] = string(rune('a' + r.Intn(4)))
+		}
+		var gotA, gotB []string
+		kept := 0
+		ops := diffTokens(a, b)
+		for k, op := range ops {
+			if op.kind != '+' {
+				gotA = append(gotA, op.text)
+			}
+			if op.kind != '-' {
+				gotB = append(gotB, op.text)
+			}
+			if op.kind == ' ' {
+				kept++
+			}
+			if op.kind == '-' && k > 0 && ops[k-1].kind == '+' {
+				t.Fatalf("diffTokens(%q, %q) adds before it removes", a, b)
+			}
+		}
+		if !slices.Equal(gotA, a) || !slices.Equal(gotB, b) || kept != lcs(a, b) {
+			t.Fatalf("diffTokens(%q, %q) = %v, not a shortest edit script", a, b, ops)
+		}
+	}
+
+	// A long file with a single change stays cheap
+	long := strings.Repeat("line\n", 200000)
+	var buf bytes.Buffer
+	New(&buf).Diff(long, long+"tail\n")
+	if !strings.HasSuffix(StripANSI(buf.String()), "+ tail\n") {
+		t.Errorf("Diff(long) = %q", buf.String())
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+	t.Setenv("COLUMNS", "83")
+
+	var buf bytes.Buffer
+	New(&buf).SideBySide("x\nold value\n", "x\nnew value\nadded\n")
+	want := []string{
+		"@@ -1,2 +1,3 @@",
+		"  x" + strings.Repeat(" ", 37) + " │   x",
+		"- old value" + strings.Repeat(" ", 29) + " │ + new value",
+		strings.Repeat(" ", 40) + " │ + added",
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if !slices.Equal(lines, want) {
+		t.Fatalf("SideBySide() = %q, want %q", lines, want)
+	}
+
+	buf.Reset()
+	t.Setenv("COLUMNS", "40")
+	New(&buf).SideBySide("a\n", "b\n")
+	if got := buf.String(); got != "@@ -1,1 +1,1 @@\n- a\n+ b\n" {
+		t.Errorf("narrow SideBySide() = %q, want unified output", got)
+	}
+}
+
+func TestRemapLevel(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf, WithLevel(InfoLevel))
+	lib := n.With("lib").RemapLevel(ErrorLevel, WarnLevel).RemapLevel(InfoLevel, DebugLevel)
+	lib.Error("connection reset")
+	lib.Info("handshake done")
+	n.Error("own error")
+
+	want := fmt.Sprintf("%s [lib] connection reset\n%s own error\n", symbols[WarnLevel], symbols[ErrorLevel])
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if s := n.Stats(); s.Counts[WarnLevel] != 1 || s.Counts[ErrorLevel] != 1 {
+		t.Errorf("Stats() = %+v, want the remapped level counted", s.Counts)
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	req := n.WithRequestID()
+	id := req.RequestID()
+	if !regexp.MustCompile(`^[0-9a-z]{8}$`).MatchString(id) || n.RequestID() != "" {
+		t.Fatalf("RequestID() = %q, parent %q", id, n.RequestID())
+	}
+	req.Info("served")
+	if got, want := buf.String(), symbols[InfoLevel]+" served request_id="+id+"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if other := n.WithRequestID().RequestID(); other == id {
+		t.Errorf("second ID = %q, want a new one", other)
+	}
+
+	for name, tc := range map[string]struct {
+		gen     IDGenerator
+		pattern string
+	}{
+		"UUID": {UUID, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		"ULID": {ULID, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`},
+	} {
+		id := New(&buf, WithIDGenerator(tc.gen)).WithRequestID().RequestID()
+		if !regexp.MustCompile(tc.pattern).MatchString(id) {
+			t.Errorf("%s ID = %q, want match for %s", name, id, tc.pattern)
+		}
+	}
+	first := ULID()
+	time.Sleep(2 * time.Millisecond)
+	if second := ULID(); second[:10] <= first[:10] {
+		t.Errorf("ULID() = %q after %q, want later IDs to sort later", second, first)
+	}
+}
+
+func TestWithService(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skip("no hostname:", err)
+	}
+	var buf bytes.Buffer
+	New(&buf, WithService("billing", "1.4.2")).Info("started")
+	want := fmt.Sprintf("%s started host=%s pid=%d service=billing version=1.4.2\n", symbols[InfoLevel], host, os.Getpid())
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	New(&buf, WithJSONFormat(), WithService("billing", "")).Info("started")
+	var got struct{ Fields map[string]any }
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Fields["service"] != "billing" || got.Fields["pid"] != float64(os.Getpid()) || got.Fields["host"] != host {
+		t.Errorf("fields = %v", got.Fields)
+	}
+	if _, ok := got.Fields["version"]; ok {
+		t.Errorf("fields = %v, want no empty version", got.Fields)
+	}
+}
+
+func TestWithWorker(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.With("pool").WithWorker("w3").Info("picked job")
+	if got, want := buf.String(), symbols[InfoLevel]+" [pool] w3 picked job\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	g := New(&buf, WithGoroutineID())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Info("from goroutine")
+	}()
+	<-done
+	g.Info("from test")
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	re := regexp.MustCompile(`^\S+ g(\d+) from (goroutine|test)$`)
+	if len(lines) != 2 || !re.MatchString(lines[0]) || !re.MatchString(lines[1]) {
+		t.Fatalf("output = %q, want goroutine labels", lines)
+	}
+	if re.FindStringSubmatch(lines[0])[1] == re.FindStringSubmatch(lines[1])[1] {
+		t.Errorf("output = %q, want different goroutine IDs", lines)
+	}
+
+	buf.Reset()
+	New(&buf, WithJSONFormat()).WithWorker("w1").Info("done")
+	if !strings.Contains(buf.String(), `"worker":"w1"`) {
+		t.Errorf("JSON output = %q, want a worker key", buf.String())
+	}
+}
+
+func TestTraceLevel(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.Trace("hidden by default")
+	if buf.Len() != 0 {
+		t.Fatalf("Trace() at the default level wrote %q", buf.String())
+	}
+	n = New(&buf, WithLevel(TraceLevel))
+	n.Trace("frame %d", 7)
+	if got, want := buf.String(), symbols[TraceLevel]+" frame 7\n"; got != want {
+		t.Errorf("Trace() = %q, want %q", got, want)
+	}
+	if TraceLevel.String() != "trace" || !(TraceLevel < DebugLevel && DebugLevel == 0) {
+		t.Errorf("TraceLevel = %d %q, want below DebugLevel", TraceLevel, TraceLevel)
+	}
+
+	custom := InfoLevel + 5
+	if !(InfoLevel < custom && custom < NoticeLevel) {
+		t.Errorf("no room between InfoLevel %d and NoticeLevel %d", InfoLevel, NoticeLevel)
+	}
+	if level, _ := DetectLevel("TRACE read 12 bytes", DefaultLevelRules); level != TraceLevel {
+		t.Errorf("DetectLevel(TRACE) = %v, want trace", level)
+	}
+
+	n = New(&buf, WithLevel(TraceLevel), WithExitCodes(map[LogLevel]int{TraceLevel: 3}))
+	n.Trace("anything")
+	if code := n.ExitCode(); code != 3 {
+		t.Errorf("ExitCode() = %d, want 3 for a Trace threshold", code)
+	}
+}
+
+func TestSetStreams(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var out, errs bytes.Buffer
+	n := New(&out)
+	split := SplitStreams
+	split.Errors = &errs
+	n.SetStreams(&split)
+	n.Info("fetching")
+	n.Warn("slow mirror")
+	n.Printf(NoLevel, "result")
+	if out.String() != symbols[InfoLevel]+" fetching\nresult\n" || errs.String() != symbols[WarnLevel]+" slow mirror\n" {
+		t.Errorf("split: stdout %q, stderr %q", out.String(), errs.String())
+	}
+
+	out.Reset()
+	errs.Reset()
+	posix := POSIXStreams
+	posix.Errors = &errs
+	j := New(&out, WithJSONFormat(), WithLevel(TraceLevel))
+	j.SetStreams(&posix)
+	j.Trace("detail")
+	j.Printf(NoLevel, "data")
+	if !strings.Contains(out.String(), `"msg":"data"`) || strings.Count(errs.String(), "\n") != 1 || !strings.Contains(errs.String(), `"msg":"detail"`) {
+		t.Errorf("posix: stdout %q, stderr %q", out.String(), errs.String())
+	}
+
+	out.Reset()
+	errs.Reset()
+	n.Hold()
+	n.Error("failed")
+	n.Info("held")
+	n.Discard()
+	if out.Len() != 0 || errs.String() != symbols[ErrorLevel]+" failed\n" {
+		t.Errorf("hold: stdout %q, stderr %q, want stderr not held", out.String(), errs.String())
+	}
+
+	n.SetStreams(nil)
+	n.Error("back")
+	if out.String() != symbols[ErrorLevel]+" back\n" {
+		t.Errorf("SetStreams(nil): stdout %q", out.String())
+	}
+}
+
+func TestOut(t *testing.T) {
+	var out, errs, file bytes.Buffer
+	n := New(&out, WithColorMode(ColorAlways), WithJSONFormat())
+	n.AddSink(FileSink, &file)
+	n.SetStreams(&StreamPolicy{Stderr: math.MinInt, Errors: &errs})
+	n.Use(func(e Entry) []Entry { return nil })
+	n.Hold()
+
+	n.Out("%s\t%d", "main.go", 120)
+	n.Info("counted")
+	n.Discard()
+	if got := out.String(); got != "main.go\t120\n" {
+		t.Errorf("stdout = %q, want the bare line", got)
+	}
+	if file.Len() != 0 || errs.Len() != 0 {
+		t.Errorf("sink %q, stderr %q, want Out kept off both", file.String(), errs.String())
+	}
+}
+
+func TestSetPorcelain(t *testing.T) {
+	SetPorcelain(true)
+	defer SetPorcelain(false)
+
+	var buf bytes.Buffer
+	n := New(&buf, WithColorMode(ColorAlways))
+	n.With("git").Logf(WarnLevel, "dirty tree")
+	n.Info("done")
+	if got, want := buf.String(), "[git] dirty tree\ndone\n"; got != want {
+		t.Errorf("porcelain output = %q, want %q", got, want)
+	}
+	if animated() {
+		t.Error("animated() = true in porcelain mode")
+	}
+
+	buf.Reset()
+	SetPorcelain(false)
+	n.Info("done")
+	if !strings.Contains(buf.String(), "\x1b[") || !strings.Contains(buf.String(), symbols[InfoLevel]) {
+		t.Errorf("output after SetPorcelain(false) = %q, want decorations back", buf.String())
+	}
+}
+
+func TestPreview(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	type user struct {
+		ID    int               `json:"id"`
+		Name  string            `json:"name"`
+		Admin bool              `json:"admin"`
+		Tags  []string          `json:"tags"`
+		Meta  map[string]any    `json:"meta"`
+		Extra map[string]string `json:"x-extra"`
+	}
+	u := user{ID: 7, Name: "bob", Tags: []string{"a", "b"}, Meta: map[string]any{"z": nil, "a": 1.5}}
+	full := `{id: 7, name: "bob", admin: false, tags: ["a", "b"], meta: {a: 1.5, z: null}, "x-extra": null}`
+	for _, tc := range []struct {
+		width int
+		want  string
+	}{
+		{0, full},
+		{len(full), full},
+		{len(full) - 1, full[:len(full)-2] + "…"},
+		{16, `{id: 7, name: "…`},
+		{15, `{id: 7, name: …`},
+	} {
+		if got := Preview(u, tc.width).String(); got != tc.want {
+			t.Errorf("Preview(%d) = %q, want %q", tc.width, got, tc.want)
+		}
+	}
+	if got := Preview(func() {}, 10).String(); !strings.HasPrefix(got, "0x") {
+		t.Errorf("Preview(func) = %q, want the fmt form", got)
+	}
+
+	color.NoColor = false
+	if got := Preview(map[string]int{"n": 1}, 0).String(); got != "{"+previewKey.Sprint("n")+": "+previewNumber.Sprint("1")+"}" {
+		t.Errorf("colored Preview = %q", got)
+	}
+}
+
+func TestSetKeyOrder(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+	SetKeyOrder(KeyOrder{Sort: true, First: []string{"id", "error"}, OmitEmpty: true})
+	defer SetKeyOrder(KeyOrder{})
+
+	type record struct {
+		Zeta  int            `json:"zeta"`
+		Note  string         `json:"note"`
+		Error string         `json:"error"`
+		ID    int            `json:"id"`
+		Alpha map[string]any `json:"alpha"`
+	}
+	v := record{Zeta: 0, Error: "boom", ID: 3, Alpha: map[string]any{"b": 1, "id": 2, "x": []int{}}}
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.JSON(v)
+	if got, want := strings.TrimSpace(buf.String()), `{"id":3,"error":"boom","alpha":{"id":2,"b":1},"zeta":0}`; got != want {
+		t.Errorf("JSON() = %q, want %q", got, want)
+	}
+	if got, want := Preview(v, 0).String(), `{id: 3, error: "boom", alpha: {id: 2, b: 1}, zeta: 0}`; got != want {
+		t.Errorf("Preview() = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	var owner *struct{ Name string }
+	n.WithFields(Fields{"user": "ann", "id": 9, "empty": "", "tags": []string{}, "meta": map[string]int{}, "owner": owner}).Info("login")
+	if got, want := buf.String(), symbols[InfoLevel]+" login id=9 user=ann\n"; got != want {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	New(&buf, WithJSONFormat()).WithFields(Fields{"user": "ann", "id": 9}).Info("login")
+	if !strings.Contains(buf.String(), `"fields":{"id":9,"user":"ann"}`) {
+		t.Errorf("JSON entry = %q, want ordered fields", buf.String())
+	}
+}
+
+// fakeFieldError mimics validator.FieldError for FromValidator
+type fakeFieldError struct{ ns, tag, param string }
+
+func (f fakeFieldError) Namespace() string { return f.ns }
+func (f fakeFieldError) Tag() string       { return f.tag }
+func (f fakeFieldError) Param() string     { return f.param }
+func (f fakeFieldError) Value() any        { return 12 }
+
+// fakeValidationErrors mimics validator.ValidationErrors
+type fakeValidationErrors []fakeFieldError
+
+func (fakeValidationErrors) Error() string { return "invalid" }
+
+// fakeSchemaError mimics gojsonschema.ResultError
+type fakeSchemaError struct{ field string }
+
+func (f fakeSchemaError) Field() string       { return f.field }
+func (f fakeSchemaError) Description() string { return "bad" }
+func (f fakeSchemaError) Value() any          { return nil }
+
+func TestValidationErrors(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	New(&buf).ValidationErrors([]FieldError{
+		{Path: "user.email", Message: "must be a valid email", Value: "bob@"},
+		{Path: "user.age", Message: "must be at least 18", Value: 12},
+		{Path: "user.email", Message: "is too short"},
+		{Message: "unexpected key", Value: map[string]int{"x": 1}},
+	})
+	want := symbols[ErrorLevel] + " 4 validation errors\n" +
+		"  user.email\n" +
+		"    • must be a valid email got \"bob@\"\n" +
+		"    • is too short\n" +
+		"  user.age\n" +
+		"    • must be at least 18 got 12\n" +
+		"  (root)\n" +
+		"    • unexpected key got {x: 1}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("ValidationErrors() = %q, want %q", got, want)
+	}
+
+	errs := FromValidator(fakeValidationErrors{{"User.Age", "min", "18"}, {"User.Name", "required", ""}, {"User.Code", "hexcolor", ""}})
+	wantErrs := []FieldError{{"Age", "must be at least 18", 12}, {"Name", "is required", 12}, {"Code", "failed hexcolor", 12}}
+	if !slices.Equal(errs, wantErrs) {
+		t.Errorf("FromValidator() = %v, want %v", errs, wantErrs)
+	}
+	if FromValidator(errors.New("plain")) != nil {
+		t.Error("FromValidator(plain error) != nil")
+	}
+
+	schema := FromJSONSchema([]fakeSchemaError{{"(root)"}, {"items.0"}})
+	if len(schema) != 2 || schema[0].Path != "" || schema[1].Path != "items.0" || schema[1].Message != "bad" {
+		t.Errorf("FromJSONSchema() = %v", schema)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.String("output", "plan.txt", "write the `file` here")
+	fs.Bool("v", false, "print more")
+	fs.Int("retries", 3, "attempts per request")
+
+	spec := FlagSetUsage(fs)
+	spec.Summary = "deploys services"
+	spec.Commands = []UsageCommand{{"status", "show state"}, {"rollback", "undo"}}
+	spec.Examples = []string{"app -v status"}
+
+	var buf bytes.Buffer
+	New(&buf).Usage(spec)
+	want := "app - deploys services\n" +
+		"\n" +
+		"USAGE\n" +
+		"  app [flags]\n" +
+		"\n" +
+		"COMMANDS\n" +
+		"  status     show state\n" +
+		"  rollback   undo\n" +
+		"\n" +
+		"FLAGS\n" +
+		"      --output file   write the file here (default \"plan.txt\")\n" +
+		"      --retries int   attempts per request (default 3)\n" +
+		"  -v                  print more\n" +
+		"\n" +
+		"EXAMPLES\n" +
+		"   $ app -v status \n"
+	if got := buf.String(); got != want {
+		t.Errorf("Usage() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestUpdateAvailable tests the banner, its reminder file and its stream
+func TestUpdateAvailable(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	file := filepath.Join(t.TempDir(), "update.json")
+	n.UpdateAvailable("v1.2.0", "v1.3.0", "https://example.com/releases", ReminderFile(file))
+	want := "╭──────────────────────────────────────╮\n" +
+		"│                                      │\n" +
+		"│   Update available v1.2.0 → v1.3.0   │\n" +
+		"│     https://example.com/releases     │\n" +
+		"│                                      │\n" +
+		"╰──────────────────────────────────────╯\n"
+	if got := buf.String(); got != want {
+		t.Errorf("UpdateAvailable() =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	n.UpdateAvailable("v1.2.0", "v1.3.0", "https://example.com/releases", ReminderFile(file))
+	n.UpdateAvailable("v1.3.0", "1.3.0", "", RemindEvery(0), ReminderFile(file))
+	if buf.Len() != 0 {
+		t.Errorf("UpdateAvailable() repeated within a day or for the same version:\n%s", buf.String())
+	}
+	n.UpdateAvailable("v1.2.0", "v1.4.0", "", ReminderFile(file))
+	if !strings.Contains(buf.String(), "v1.2.0 → v1.4.0") {
+		t.Errorf("UpdateAvailable() skipped a newer release:\n%s", buf.String())
+	}
+	if got := StripANSI(osc8("https://x.io", "x")); got != "x" {
+		t.Errorf("StripANSI(osc8()) = %q", got)
+	}
+
+	var stdout, stderr bytes.Buffer
+	n = New(&stdout)
+	n.SetStreams(&StreamPolicy{Stderr: POSIXStreams.Stderr, Errors: &stderr})
+	n.UpdateAvailable("v1.2.0", "v1.3.0", "", RemindEvery(0), ReminderFile(""))
+	if stdout.Len() != 0 || !strings.Contains(stderr.String(), "v1.2.0 → v1.3.0") {
+		t.Errorf("UpdateAvailable() with POSIXStreams wrote stdout %q, stderr %q", stdout.String(), stderr.String())
+	}
+}
+
+// waitForOutput polls buf until it contains want or timeout passes
+// Keeps tests of background output independent of scheduling delays
+func waitForOutput(buf *syncBuffer, want string, timeout time.Duration) bool {
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(5 * time.Millisecond) {
+		if strings.Contains(buf.String(), want) {
+			return true
+		}
+	}
+	return strings.Contains(buf.String(), want)
+}
+
+// TestDeadline tests the warning and error of a watched deadline
+func TestDeadline(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+	SetDeadlineThreshold(400 * time.Millisecond)
+	defer SetDeadlineThreshold(10 * time.Second)
+
+	var buf syncBuffer
+	n := New(&buf)
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	n.Deadline(ctx, "sync")
+	if !waitForOutput(&buf, "[✘] sync: deadline exceeded", 5*time.Second) {
+		t.Errorf("Deadline() did not report the exceeded deadline: %q", buf.String())
+	}
+	if out := buf.String(); !strings.Contains(out, "[⚠] sync: ") || !strings.Contains(out, "left before deadline") {
+		t.Errorf("Deadline() did not warn before the deadline: %q", out)
+	}
+
+	var quiet syncBuffer
+	n = New(&quiet)
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	n.Deadline(ctx, "fast")
+	n.Deadline(context.Background(), "unbounded")
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	if quiet.String() != "" {
+		t.Errorf("Deadline() logged for work finished in time: %q", quiet.String())
+	}
+}
+
+// TestRetry tests attempt reporting and the final outcome of Retry
+func TestRetry(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	t.Run("eventual success", func(t *testing.T) {
+		var buf bytes.Buffer
+		n := New(&buf)
+
+		calls := 0
+		err := Retry(n, 3, time.Millisecond, func() error {
+			calls++
+			if calls < 2 {
+				return errors.New("boom")
+			}
+			return nil
+		})
+
+		if err != nil {
+			t.Fatalf("Retry() returned %v, want nil", err)
+		}
+		output := buf.String()
+		if !strings.Contains(output, "attempt 1/3 failed: boom, retrying in 1ms") {
+			t.Errorf("Retry() expected attempt report, got: %q", output)
+		}
+		if !strings.Contains(output, "✓ succeeded on attempt 2/3") {
+			t.Errorf("Retry() expected success line, got: %q", output)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		var buf bytes.Buffer
+		n := New(&buf)
+
+		err := Retry(n, 2, time.Millisecond, func() error { return errors.New("still down") })
+
+		if err == nil || err.Error() != "still down" {
+			t.Fatalf("Retry() returned %v, want last error", err)
+		}
+		output := buf.String()
+		if !strings.Contains(output, "✗ failed after 2 attempts: still down") {
+			t.Errorf("Retry() expected failure line, got: %q", output)
+		}
+	})
+}
+
+// TestCSV tests table layout, footer and truncation of CSV output
+func TestCSV(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	t.Run("aligned", func(t *testing.T) {
+		var buf bytes.Buffer
+		n := New(&buf)
+
+		n.CSV(strings.NewReader("name,age\nalice,30\nbob,4\n"))
+
+		want := "name   age\n─────  ───\nalice  30\nbob    4\n(2 rows)\n"
+		if got := buf.String(); got != want {
+			t.Errorf("CSV() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Setenv("COLUMNS", "20")
+		var buf bytes.Buffer
+		n := New(&buf)
+
+		n.CSV(strings.NewReader("id,description\n1," + strings.Repeat("x", 40) + "\n"))
+
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if w := displayWidth(line); w > 20 {
+				t.Errorf("CSV() line %q is %d columns wide, want <= 20", line, w)
+			}
+		}
+		if !strings.Contains(buf.String(), "…") {
+			t.Errorf("CSV() expected truncation marker, got: %q", buf.String())
+		}
+	})
+}
+
+// TestXMLBytes tests re-indentation of raw XML documents
+func TestXMLBytes(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	n.XMLBytes([]byte(`<?xml version="1.0"?><soap:Envelope xmlns:soap="urn:x"><soap:Body><ping id="a&amp;b">hello &amp; bye</ping><empty/></soap:Body></soap:Envelope>`))
+
+	want := `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="urn:x">
+  <soap:Body>
+    <ping id="a&amp;b">hello &amp; bye</ping>
+    <empty></empty>
+  </soap:Body>
+</soap:Envelope>
+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("XMLBytes() = %q, want %q", got, want)
+	}
+}
+
+// TestXML tests marshaling values to XML
+func TestXML(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	type user struct {
+		Name string `xml:"name,attr"`
+		Role string `xml:"role"`
+	}
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.XML(user{Name: "bob", Role: "admin"})
+
+	want := "<user name=\"bob\">\n  <role>admin</role>\n</user>\n\n"
+	if got := buf.String(); got != want {
+		t.Errorf("XML() = %q, want %q", got, want)
+	}
+}
+
+// TestTOML tests encoding of scalars, tables and arrays of tables
+func TestTOML(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	type server struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+	}
+	type config struct {
+		Title   string            `toml:"title"`
+		Debug   bool              `toml:"debug"`
+		Ratio   float64           `toml:"ratio"`
+		Tags    []string          `toml:"tags"`
+		Labels  map[string]string `toml:"labels"`
+		Servers []server          `toml:"servers"`
+		Secret  string            `toml:"-"`
+		Note    string            `toml:"note,omitempty"`
+	}
+
+	var buf bytes.Buffer
+	n := New(&buf)
+	n.TOML(config{
+		Title:   "demo \"app\"",
+		Debug:   true,
+		Ratio:   2,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"team": "core", "cost center": "42"},
+		Servers: []server{{"alpha", 80}, {"beta", 81}},
+		Secret:  "hidden",
+	})
+
+	want := `title = "demo \"app\""
+debug = true
+ratio = 2.0
+tags = ["a", "b"]
+
+[labels]
+"cost center" = "42"
+team = "core"
+
+[[servers]]
+host = "alpha"
+port = 80
+
+[[servers]]
+host = "beta"
+port = 81
+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("TOML() = %q, want %q", got, want)
+	}
+}
+
+// TestTOMLCycle tests that self-referential values fail instead of recursing
+func TestTOMLCycle(t *testing.T) {
+	type node struct {
+		Name string `toml:"name"`
+		Next *node  `toml:"next"`
+	}
+	loop := &node{Name: "a"}
+	loop.Next = &node{Name: "b", Next: loop}
+	self := map[string]any{"name": "root"}
+	self["self"] = self
+
+	n := New(&bytes.Buffer{})
+	for _, v := range []any{loop, self} {
+		if err := n.TOMLE(v); err == nil || !strings.Contains(err.Error(), "cyclic") {
+			t.Errorf("TOMLE(%T) = %v, want a cycle error", v, err)
+		}
+	}
+	shared := &node{Name: "leaf"}
+	if err := n.TOMLE(map[string]*node{"x": shared, "y": shared}); err != nil {
+		t.Errorf("TOMLE() of a shared pointer = %v", err)
+	}
+}
+
+// TestNDJSON tests printing of values received from a channel
+func TestNDJSON(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	ch := make(chan any, 2)
+	ch <- map[string]int{"seq": 1}
+	ch <- map[string]int{"seq": 2}
+	close(ch)
+	n.NDJSON(ch)
+
+	if got, want := buf.String(), "{\"seq\":1}\n{\"seq\":2}\n"; got != want {
+		t.Errorf("NDJSON() = %q, want %q", got, want)
+	}
+}
+
+// TestNDJSONWriter tests line splitting and passthrough of invalid records
+func TestNDJSONWriter(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	n := New(&buf)
+
+	w := n.NDJSONWriter()
+	io.Copy(w, strings.NewReader("{\"a\": 1}\n\nnot json\n{\"b\":"))
+	if strings.Contains(buf.String(), "{\"b\":") {
+		t.Fatalf("NDJSONWriter() printed a partial line early: %q", buf.String())
+	}
+	w.Close()
+
+	if got, want := buf.String(), "{\"a\":1}\nnot json\n{\"b\":\n"; got != want {
+		t.Errorf("NDJSONWriter() = %q, want %q", got, want)
+	}
+}
+
+// TestDisplayWidth tests column counting for wide and zero width runes
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"hello", 5},
+		{"\x1b[31mred\x1b[0m", 3},
+		{"日本語", 6},
+		{"e\u0301te\u0301", 3},  // combining acute accents
+		{"\u202bשלום\u202c", 4}, // bidi embedding marks
+		{"👍 ok", 5},
+		{"ｆｕｌｌ", 8},
+	}
+	for _, tt := range tests {
+		if got := displayWidth(tt.in); got != tt.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestTruncate tests that truncation respects column widths
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		w    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"abcdef", 4, "abc…"},
+		{"日本語テキスト", 6, "日本…"},
+		{"日本語テキスト", 5, "日本…"},
+		{"café latte", 6, "café …"},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.in, tt.w)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.w, got, tt.want)
+		}
+		if displayWidth(got) > tt.w {
+			t.Errorf("truncate(%q, %d) is %d columns wide", tt.in, tt.w, displayWidth(got))
+		}
+	}
+}
+
+// TestTail tests following appended lines and level detection
+func TestTail(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	interval := tailPollInterval
+	tailPollInterval = 5 * time.Millisecond
+	defer func() { tailPollInterval = interval }()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("old line\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf syncBuffer
+	n := New(&buf)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- n.Tail(ctx, path, []LevelRule{Rule(`ERROR`, ErrorLevel)})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("ERROR disk full\nplain line\n")
+	f.Close()
+
+	waitForOutput(&buf, "plain line", 2*time.Second)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "old line") {
+		t.Errorf("Tail() re-emitted existing content: %q", output)
+	}
+	if !strings.Contains(output, "[✘] ERROR disk full\n") || !strings.Contains(output, "plain line\n") {
+		t.Errorf("Tail() unexpected output: %q", output)
+	}
+}
@@ -0,0 +1,96 @@
+// Package auroracli wires aurora into command line frameworks
+// It builds on the standard flag package only: cobra takes the flags through
+// pflag's AddGoFlagSet and urfave/cli calls Apply from a Before hook, so
+// neither framework becomes a dependency of aurora
+package auroracli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/olekukonko/aurora"
+	"strings"
+)
+
+// Settings are the output switches common to command line tools
+type Settings struct {
+	Verbose bool // Show Debug entries and Verbose blocks
+	Quiet   bool // Show only warnings and worse
+	NoColor bool // Strip all color
+}
+
+// Apply reconfigures aurora.Default in place according to s
+// Quiet wins over Verbose; code holding aurora.Default sees the change,
+// so call it before the command starts logging
+func Apply(s Settings) {
+	switch {
+	case s.Quiet:
+		aurora.SetLevel(aurora.WarnLevel)
+	case s.Verbose:
+		aurora.SetLevel(aurora.DebugLevel)
+		aurora.SetVerbose(true)
+	}
+	if s.NoColor {
+		aurora.SetColorMode(aurora.ColorNever)
+	}
+}
+
+// Register adds --verbose, --quiet and --no-color to fs
+// The returned Settings fill in while fs is parsed; pass them to Apply
+// afterwards so the flags combine the same in any order. With cobra:
+//
+//	fs := flag.NewFlagSet("output", flag.ContinueOnError)
+//	settings := auroracli.Register(fs)
+//	root.PersistentFlags().AddGoFlagSet(fs)
+//	root.PersistentPreRun = func(*cobra.Command, []string) { auroracli.Apply(*settings) }
+func Register(fs *flag.FlagSet) *Settings {
+	s := new(Settings)
+	fs.BoolVar(&s.Verbose, "verbose", false, "show debug output")
+	fs.BoolVar(&s.Quiet, "quiet", false, "show only warnings and errors")
+	fs.BoolVar(&s.NoColor, "no-color", false, "disable colored output")
+	return s
+}
+
+// For returns a Notifier prefixed with the subcommand of commandPath
+// e.g. For(cmd.CommandPath()) with "app deploy staging" prefixes entries
+// with "deploy staging"; the root command gets aurora.Default itself
+func For(commandPath string) *aurora.Notifier {
+	_, sub, found := strings.Cut(strings.TrimSpace(commandPath), " ")
+	if !found {
+		return aurora.Default
+	}
+	return aurora.Default.With(sub)
+}
+
+// Fail prints err as an error and returns the exit status for it
+// Meant for main with cobra's SilenceErrors or urfave/cli's ExitErrHandler:
+//
+//	if err := root.Execute(); err != nil {
+//		os.Exit(auroracli.Fail(err))
+//	}
+func Fail(err error) int {
+	if err == nil {
+		return 0
+	}
+	aurora.Error("%v", err)
+	aurora.Flush()
+	return 1
+}
+
+// FlagError wraps a flag parsing error of command with a hint to its help
+// Matches cobra's SetFlagErrorFunc once the command path is passed in
+func FlagError(command string, err error) error {
+	return fmt.Errorf("%w\nRun '%s --help' for usage", err, command)
+}
+
+// UsageFunc returns a flag.FlagSet Usage function printing aurora styled help
+// Customize the spec to add a summary or examples, e.g. for cobra's
+// SetUsageFunc built from the command's fields
+func UsageFunc(fs *flag.FlagSet, customize ...func(*aurora.UsageSpec)) func() {
+	return func() {
+		spec := aurora.FlagSetUsage(fs)
+		for _, fn := range customize {
+			fn(&spec)
+		}
+		aurora.Usage(spec)
+	}
+}
@@ -0,0 +1,83 @@
+package auroracli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"github.com/fatih/color"
+	"github.com/olekukonko/aurora"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestRegister tests that the parsed flags reconfigure aurora.Default in place
+func TestRegister(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	for _, tc := range []struct {
+		args  []string
+		debug bool
+		info  bool
+	}{
+		{nil, false, true},
+		{[]string{"--verbose"}, true, true},
+		{[]string{"--verbose=false"}, false, true},
+		{[]string{"--quiet"}, false, false},
+		{[]string{"--verbose", "--quiet"}, false, false},
+		{[]string{"--quiet", "--verbose"}, false, false},
+	} {
+		var buf bytes.Buffer
+		root := aurora.New(&buf, aurora.WithLevel(aurora.InfoLevel))
+		aurora.Default = root
+		fs := flag.NewFlagSet("app", flag.ContinueOnError)
+		settings := Register(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatal(err)
+		}
+		Apply(*settings)
+		if aurora.Default != root {
+			t.Fatalf("%v: Apply() replaced aurora.Default", tc.args)
+		}
+		aurora.Debug("debug")
+		aurora.Info("info")
+		aurora.Warn("warn")
+
+		out := buf.String()
+		if strings.Contains(out, "debug") != tc.debug || strings.Contains(out, "info") != tc.info || !strings.Contains(out, "warn") {
+			t.Errorf("%v: output %q", tc.args, out)
+		}
+	}
+	aurora.Default = aurora.New(os.Stdout)
+}
+
+// TestFor tests per-command prefixes and error helpers
+func TestFor(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	aurora.Default = aurora.New(&buf)
+	defer func() { aurora.Default = aurora.New(os.Stdout) }()
+
+	if For("app") != aurora.Default {
+		t.Error("For(root) is not aurora.Default")
+	}
+	For("app deploy staging").Info("rolling out")
+	if !strings.Contains(buf.String(), "[deploy staging] rolling out") {
+		t.Errorf("For() output = %q", buf.String())
+	}
+
+	buf.Reset()
+	if code := Fail(errors.New("boom")); code != 1 || !strings.Contains(buf.String(), "boom") {
+		t.Errorf("Fail() = %d, output %q", code, buf.String())
+	}
+	if Fail(nil) != 0 {
+		t.Error("Fail(nil) != 0")
+	}
+	cause := errors.New("unknown flag --x")
+	if err := FlagError("app deploy", cause); !errors.Is(err, cause) || !strings.Contains(err.Error(), "app deploy --help") {
+		t.Errorf("FlagError() = %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package auroralog mirrors the standard library log package on top of aurora
+// Every function has the signature of its log counterpart and writes through
+// aurora.Default, so call sites can migrate by changing a single import
+//
+// Unlike log, output goes wherever aurora.Default writes, os.Stdout unless
+// redirected; call SetOutput(os.Stderr) to keep stdout free for data
+package auroralog
+
+import (
+	"fmt"
+	"github.com/olekukonko/aurora"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Settings of the package logger, guarded by mu
+var (
+	mu     sync.Mutex
+	flags  = log.LstdFlags
+	prefix string
+)
+
+// SetOutput sets the destination of aurora.Default like log.SetOutput
+func SetOutput(w io.Writer) { aurora.SetOutput(w) }
+
+// Writer returns the writer aurora.Default currently writes to
+// os.Stdout unless SetOutput or aurora.SetOutput chose another
+func Writer() io.Writer { return aurora.Default.Destination() }
+
+// SetFlags sets the output flags like log.SetFlags
+// Any of Ldate, Ltime and Lmicroseconds stamps lines in aurora's time format;
+// without them lines carry no timestamp. File flags are not supported
+func SetFlags(flag int) {
+	mu.Lock()
+	defer mu.Unlock()
+	flags = flag
+}
+
+// Flags returns the flags set with SetFlags, log.LstdFlags by default
+func Flags() int {
+	mu.Lock()
+	defer mu.Unlock()
+	return flags
+}
+
+// SetPrefix sets text placed before every message like log.SetPrefix
+// The prefix follows aurora's level symbol, as with log.Lmsgprefix
+func SetPrefix(p string) {
+	mu.Lock()
+	defer mu.Unlock()
+	prefix = p
+}
+
+// Prefix returns the prefix set with SetPrefix
+func Prefix() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return prefix
+}
+
+// Output logs s at Info level like log.Output
+// calldepth is accepted for compatibility; aurora does not report files
+func Output(calldepth int, s string) error {
+	output(aurora.InfoLevel, s)
+	return nil
+}
+
+// Print logs its operands like log.Print at Info level
+func Print(v ...any) { output(aurora.InfoLevel, fmt.Sprint(v...)) }
+
+// Printf logs a formatted message like log.Printf at Info level
+func Printf(format string, v ...any) { output(aurora.InfoLevel, fmt.Sprintf(format, v...)) }
+
+// Println logs its operands like log.Println at Info level
+func Println(v ...any) { output(aurora.InfoLevel, fmt.Sprintln(v...)) }
+
+// Fatal logs like Print at Critical level and exits with status 1
+func Fatal(v ...any) {
+	output(aurora.CriticalLevel, fmt.Sprint(v...))
+	aurora.Flush()
+	os.Exit(1)
+}
+
+// Fatalf logs like Printf at Critical level and exits with status 1
+func Fatalf(format string, v ...any) {
+	output(aurora.CriticalLevel, fmt.Sprintf(format, v...))
+	aurora.Flush()
+	os.Exit(1)
+}
+
+// Fatalln logs like Println at Critical level and exits with status 1
+func Fatalln(v ...any) {
+	output(aurora.CriticalLevel, fmt.Sprintln(v...))
+	aurora.Flush()
+	os.Exit(1)
+}
+
+// Panic logs like Print at Critical level and panics with the message
+func Panic(v ...any) {
+	s := fmt.Sprint(v...)
+	output(aurora.CriticalLevel, s)
+	aurora.Flush()
+	panic(s)
+}
+
+// Panicf logs like Printf at Critical level and panics with the message
+func Panicf(format string, v ...any) {
+	s := fmt.Sprintf(format, v...)
+	output(aurora.CriticalLevel, s)
+	aurora.Flush()
+	panic(s)
+}
+
+// Panicln logs like Println at Critical level and panics with the message
+func Panicln(v ...any) {
+	s := fmt.Sprintln(v...)
+	output(aurora.CriticalLevel, s)
+	aurora.Flush()
+	panic(s)
+}
+
+// Logger returns a standard *log.Logger whose lines are printed at level
+// For APIs that insist on a *log.Logger, e.g. http.Server.ErrorLog
+func Logger(level aurora.LogLevel) *log.Logger {
+	return log.New(aurora.Writer(level), "", 0)
+}
+
+// Redirect routes the standard logger through aurora.Default
+// Levels are detected from each line using aurora.DefaultLevelRules,
+// and log flags are cleared since aurora adds its own decoration
+func Redirect() {
+	log.SetFlags(0)
+	log.SetOutput(aurora.AutoLevelWriter())
+}
+
+// RedirectLevel routes the standard logger through aurora.Default at a fixed level
+// Use when existing messages carry no level keywords
+func RedirectLevel(level aurora.LogLevel) {
+	log.SetFlags(0)
+	log.SetOutput(aurora.Writer(level))
+}
+
+// output writes s with the prefix and, when flags ask for one, a timestamp
+// A single trailing newline is dropped since aurora ends every line itself
+func output(level aurora.LogLevel, s string) {
+	mu.Lock()
+	stamped, p := flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0, prefix
+	mu.Unlock()
+	s = p + strings.TrimSuffix(s, "\n")
+	if stamped {
+		aurora.Logf(level, "%s", s)
+	} else {
+		aurora.Inlinef(level, "%s", s)
+	}
+}
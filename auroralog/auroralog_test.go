@@ -0,0 +1,88 @@
+package auroralog
+
+import (
+	"bytes"
+	"github.com/fatih/color"
+	"github.com/olekukonko/aurora"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestPrintFunctions tests that the log style functions write through aurora
+func TestPrintFunctions(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	aurora.Default = aurora.New(&buf)
+	defer func() { aurora.Default = aurora.New(os.Stdout) }()
+
+	Println("server", "started")
+	Printf("port %d", 8080)
+
+	output := buf.String()
+	if !strings.Contains(output, "server started\n") || !strings.Contains(output, "port 8080\n") {
+		t.Errorf("unexpected output: %q", output)
+	}
+	if strings.Contains(output, "\n\n") {
+		t.Errorf("Println() produced a blank line: %q", output)
+	}
+}
+
+// TestRedirect tests routing the standard logger through aurora
+func TestRedirect(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	var buf bytes.Buffer
+	aurora.Default = aurora.New(&buf)
+	defer func() { aurora.Default = aurora.New(os.Stdout) }()
+
+	flags, out := log.Flags(), log.Writer()
+	defer func() {
+		log.SetFlags(flags)
+		log.SetOutput(out)
+	}()
+
+	Redirect()
+	log.Print("ERROR: disk full")
+
+	if got := buf.String(); got != "[✘] ERROR: disk full\n" {
+		t.Errorf("Redirect() = %q", got)
+	}
+}
+
+// TestSettings tests the log style output settings
+func TestSettings(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	aurora.Default = aurora.New(os.Stdout)
+	defer func() { aurora.Default = aurora.New(os.Stdout) }()
+	defer func() {
+		SetOutput(os.Stdout)
+		SetFlags(log.LstdFlags)
+		SetPrefix("")
+	}()
+
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetFlags(0)
+	SetPrefix("app: ")
+	Print("ready")
+
+	if got := buf.String(); got != "[✔] app: ready\n" {
+		t.Errorf("Print() = %q", got)
+	}
+	if Writer() != &buf || Flags() != 0 || Prefix() != "app: " {
+		t.Errorf("settings = %v, %d, %q", Writer(), Flags(), Prefix())
+	}
+
+	var other bytes.Buffer
+	aurora.SetOutput(&other)
+	if Writer() != &other {
+		t.Errorf("Writer() = %v, want the writer of aurora.Default", Writer())
+	}
+}
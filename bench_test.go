@@ -0,0 +1,133 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"io"
+	"testing"
+	"time"
+)
+
+// Benchmarks of the write path, all writing to io.Discard
+// Compare runs against testdata/bench_baseline.txt with scripts/benchcompare.sh
+
+func BenchmarkInlinef(b *testing.B) {
+	n := New(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Inlinef(InfoLevel, "request %d served in %s", i, time.Millisecond)
+	}
+}
+
+func BenchmarkLogf(b *testing.B) {
+	n := New(io.Discard).With("api")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Logf(WarnLevel, "slow request %d", i)
+	}
+}
+
+func BenchmarkPrintf(b *testing.B) {
+	n := New(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Printf(InfoLevel, "plain line")
+	}
+}
+
+func BenchmarkColored(b *testing.B) {
+	n := New(io.Discard, WithColorMode(ColorAlways))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Info("colored line %d", i)
+	}
+}
+
+func BenchmarkJSONEntry(b *testing.B) {
+	n := New(io.Discard, WithJSONFormat()).With("api")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Info("request %d", i)
+	}
+}
+
+func BenchmarkFiltered(b *testing.B) {
+	n := New(io.Discard, WithLevel(InfoLevel))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.Debug("hidden %d", i)
+	}
+}
+
+func BenchmarkConcurrent(b *testing.B) {
+	n := New(io.Discard)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		child := n.With("worker")
+		for pb.Next() {
+			child.Info("tick")
+		}
+	})
+}
+
+func BenchmarkProgress(b *testing.B) {
+	p := New(io.Discard).Progress("download", int64(b.N), WithUnit(UnitBytes))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.Add(1)
+	}
+	p.Done()
+}
+
+// TestWritePathAllocs guards the allocation counts the benchmarks measure
+// Raise a limit only with a reason; lowering it locks in an improvement
+func TestWritePathAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates on its own")
+	}
+	defer func(prev bool) { color.NoColor = prev }(color.NoColor)
+	color.NoColor = true
+
+	n := New(io.Discard)
+	quiet := New(io.Discard, WithLevel(InfoLevel))
+	for name, tc := range map[string]struct {
+		limit float64
+		fn    func()
+	}{
+		"Info":     {4, func() { n.Info("ready") }},
+		"Printf":   {2, func() { n.Printf(InfoLevel, "plain line") }},
+		"Filtered": {0, func() { quiet.Debug("hidden %d", 1) }},
+	} {
+		if allocs := testing.AllocsPerRun(100, tc.fn); allocs > tc.limit {
+			t.Errorf("%s allocates %v times per entry, limit %v", name, allocs, tc.limit)
+		}
+	}
+}
+
+// slowWriter simulates a terminal or pipe that takes a while per write
+type slowWriter struct{}
+
+func (slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(time.Microsecond)
+	return len(p), nil
+}
+
+func BenchmarkConcurrentSlow(b *testing.B) {
+	for _, async := range []bool{false, true} {
+		b.Run(fmt.Sprint("async=", async), func(b *testing.B) {
+			n := New(slowWriter{})
+			if async {
+				n.SetAsync(1024)
+				defer n.SetAsync(0)
+			}
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				child := n.With("worker")
+				for pb.Next() {
+					child.Info("tick")
+				}
+			})
+			n.Flush()
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"strings"
+)
+
+// quoteBar colors the bar that marks quoted content
+var quoteBar = color.New(color.FgCyan)
+
+// Blockquote prints text as a block quote with a colored bar and dim lines
+// Sets user input, config excerpts or upstream error bodies apart from
+// the tool's own messages; a trailing newline in text is ignored
+func (n *Notifier) Blockquote(text string) {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		b.WriteString(paint(n.force(quoteBar), "┃"))
+		if line != "" {
+			b.WriteString(" " + paint(n.force(color.New(color.Faint)), line))
+		}
+		b.WriteByte('\n')
+	}
+	n.writeBlock(b.String())
+}
+
+// Blockquote prints text as a block quote using the default Notifier
+// Named apart from the package Quote, which returns a random quote
+func Blockquote(text string) { Default.Blockquote(text) }
@@ -0,0 +1,87 @@
+package aurora
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// burstDigits matches numbers ignored when comparing repeated entries
+var burstDigits = regexp.MustCompile(`[0-9]+`)
+
+// burst counts repeats of one entry within its folding window
+type burst struct {
+	n     *Notifier // Notifier that wrote the first entry
+	level LogLevel
+	msg   string // Message of the first entry
+	count int
+	timer *time.Timer
+}
+
+// burstState tracks open folding windows of a Notifier family
+// Guarded by the Notifier mutex
+type burstState struct {
+	open map[string]*burst
+}
+
+// newBurstState creates an empty burstState
+func newBurstState() *burstState {
+	return &burstState{open: make(map[string]*burst)}
+}
+
+// fold reports whether e repeats an entry inside an open window
+// Repeats are only counted; the first entry of a window opens it
+// Internal helper; callers must hold the mutex
+func (n *Notifier) fold(e entry) bool {
+	if n.foldWindow <= 0 {
+		return false
+	}
+	key := n.repeatKey(e)
+	if b, ok := n.bursts.open[key]; ok {
+		b.count++
+		return true
+	}
+	b := &burst{n: n, level: e.Level, msg: e.Message}
+	n.bursts.open[key] = b
+	b.timer = time.AfterFunc(n.foldWindow, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		if n.bursts.open[key] == b {
+			n.bursts.flush(key)
+		}
+	})
+	return false
+}
+
+// repeatKey identifies entries that count as repeats of each other
+// Level, prefix and message must match, ignoring digits
+func (n *Notifier) repeatKey(e entry) string {
+	return fmt.Sprintf("%d\x00%s\x00%s", e.Level, e.Prefix, burstDigits.ReplaceAllString(e.Message, "#"))
+}
+
+// flush closes the window for key and writes its repeat count
+// Callers must hold the mutex
+func (s *burstState) flush(key string) {
+	b := s.open[key]
+	delete(s.open, key)
+	b.timer.Stop()
+	if b.count == 0 {
+		return
+	}
+	msg := fmt.Sprintf(tr("%s (repeated %d more %s)"), b.msg, b.count, plural(b.count, "time", "times"))
+	b.n.write(b.n.newEntry(b.level, msg))
+}
+
+// flushAll closes every open window in key order for stable output
+// Callers must hold the mutex
+func (s *burstState) flushAll() {
+	keys := make([]string, 0, len(s.open))
+	for key := range s.open {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		s.flush(key)
+	}
+}
@@ -0,0 +1,90 @@
+package aurora
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Capabilities describes what the output terminal is assumed to support
+// Decided from the environment, including terminal multiplexers that
+// hide or filter features of the terminal they run in
+type Capabilities struct {
+	Terminal    bool   // Output is a terminal
+	Animations  bool   // Live output may animate, false on CI
+	Multiplexer string // "tmux", "screen" or empty
+	SSH         bool   // Session runs over SSH
+	TrueColor   bool   // 24-bit color escape sequences are passed through
+	Hyperlinks  bool   // OSC 8 hyperlinks are rendered
+	CursorSave  bool   // Cursor save and restore (DECSC/DECRC) is reliable
+	Clipboard   bool   // OSC 52 clipboard writes reach the terminal
+	Width       int    // Usable width in columns
+}
+
+// Capabilities reports the assumptions made about the Notifier's output
+// Live features consult the same decisions
+func (n *Notifier) Capabilities() Capabilities {
+	n.mu.Lock()
+	w := n.output.w
+	n.mu.Unlock()
+
+	caps := Capabilities{
+		Terminal:    isTerminal(w),
+		Animations:  animated(),
+		Multiplexer: multiplexer(),
+		SSH:         os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "",
+		Width:       terminalWidth(),
+	}
+	if !caps.Terminal {
+		return caps
+	}
+
+	colorterm := strings.ToLower(os.Getenv("COLORTERM"))
+	caps.TrueColor = colorterm == "truecolor" || colorterm == "24bit"
+	caps.Hyperlinks = hyperlinkTerminal()
+	caps.CursorSave = true
+	caps.Clipboard = clipboardTerminal()
+
+	// GNU screen drops 24-bit color, OSC 8 and OSC 52 and restores the
+	// cursor of its own window rather than ours; tmux only forwards hyperlinks
+	// and clipboard writes when configured to, which cannot be seen from the
+	// environment
+	switch caps.Multiplexer {
+	case "screen":
+		caps.TrueColor, caps.Hyperlinks, caps.CursorSave, caps.Clipboard = false, false, false, false
+	case "tmux":
+		caps.Hyperlinks, caps.Clipboard = false, false
+	}
+	return caps
+}
+
+// multiplexer returns the terminal multiplexer the process runs in
+func multiplexer() string {
+	term := os.Getenv("TERM")
+	switch {
+	case os.Getenv("TMUX") != "" || strings.HasPrefix(term, "tmux"):
+		return "tmux"
+	case os.Getenv("STY") != "" || strings.HasPrefix(term, "screen"):
+		return "screen"
+	}
+	return ""
+}
+
+// clipboardTerminal reports whether the terminal may honor OSC 52
+// VTE based terminals and Apple Terminal are known to ignore it
+func clipboardTerminal() bool {
+	return os.Getenv("VTE_VERSION") == "" && os.Getenv("TERM_PROGRAM") != "Apple_Terminal"
+}
+
+// hyperlinkTerminal reports whether the terminal is known to render OSC 8
+func hyperlinkTerminal() bool {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	vte, _ := strconv.Atoi(os.Getenv("VTE_VERSION"))
+	return vte >= 5000
+}
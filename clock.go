@@ -0,0 +1,93 @@
+package aurora
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// coarseClock is a time source refreshed by a ticker instead of read per entry
+// The formatted timestamp is cached too, so entries within one tick share it
+type coarseClock struct {
+	now    atomic.Pointer[time.Time]
+	stamp  atomic.Pointer[coarseStamp]
+	stop   chan struct{}
+	closed sync.Once
+}
+
+// coarseStamp is the last timestamp rendered from the coarse clock
+type coarseStamp struct {
+	t      time.Time
+	layout string
+	text   string
+}
+
+// newCoarseClock starts a clock advancing every resolution
+func newCoarseClock(resolution time.Duration) *coarseClock {
+	c := &coarseClock{stop: make(chan struct{})}
+	now := time.Now()
+	c.now.Store(&now)
+	go c.tick(resolution)
+	return c
+}
+
+// tick refreshes the clock until stop is closed
+func (c *coarseClock) tick(resolution time.Duration) {
+	ticker := time.NewTicker(resolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			if old := c.now.Load(); old != nil {
+				c.now.CompareAndSwap(old, &now) // Lost to close when it already ran
+			}
+		}
+	}
+}
+
+// close stops the ticker; entries are stamped with time.Now afterwards
+// Safe to call more than once
+func (c *coarseClock) close() {
+	c.closed.Do(func() {
+		c.now.Store(nil)
+		close(c.stop)
+	})
+}
+
+// format renders t with layout, reusing the previous result for the same tick
+func (c *coarseClock) format(t time.Time, layout string) string {
+	if s := c.stamp.Load(); s != nil && s.t.Equal(t) && s.layout == layout {
+		return s.text
+	}
+	text := localTime(t, layout)
+	c.stamp.Store(&coarseStamp{t: t, layout: layout, text: text})
+	return text
+}
+
+// WithCoarseTime stamps entries from a clock refreshed every resolution
+// For very high-rate logging: time.Now and formatting then run once per
+// tick instead of once per entry. Timestamps lag real time by up to
+// resolution, and entries within one tick share a timestamp. The clock is
+// shared with derived Notifiers and replaced by a later WithCoarseTime; Close stops it
+func WithCoarseTime(resolution time.Duration) Option {
+	return func(n *Notifier) {
+		if resolution <= 0 {
+			return
+		}
+		if old := n.output.clock.Swap(newCoarseClock(resolution)); old != nil {
+			old.close()
+		}
+	}
+}
+
+// now returns the time used to stamp a new entry
+func (n *Notifier) now() time.Time {
+	if c := n.output.clock.Load(); c != nil {
+		if now := c.now.Load(); now != nil {
+			return *now
+		}
+	}
+	return time.Now()
+}
@@ -0,0 +1,58 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"regexp"
+	"slices"
+)
+
+// codeMarkup matches [code]...[/code] spans in Codef messages
+var codeMarkup = regexp.MustCompile(`\[code\](.*?)\[/code\]`)
+
+// Code is a command, path or identifier shown as an inline code span
+// e.g. n.Codef("restart with %s", aurora.Code("systemctl restart api"))
+type Code string
+
+// String renders the span on a subtle background, or in backticks
+// without color so it stays recognizable and copyable; Codef follows
+// the color mode of its Notifier instead of color.NoColor
+func (c Code) String() string {
+	if color.NoColor {
+		return "`" + string(c) + "`"
+	}
+	return commandStyle.Sprint(" " + string(c) + " ")
+}
+
+// Codef logs a message at Info level with inline code spans
+// Spans come from Code arguments or [code]...[/code] markup in the message
+func (n *Notifier) Codef(format string, args ...any) {
+	if !n.enabled(InfoLevel) {
+		return
+	}
+	args = slices.Clone(args)
+	for i, arg := range args {
+		if c, ok := arg.(Code); ok {
+			args[i] = n.span(c)
+		}
+	}
+	msg := codeMarkup.ReplaceAllStringFunc(sprintf(format, args), func(span string) string {
+		return n.span(Code(codeMarkup.FindStringSubmatch(span)[1]))
+	})
+	e := n.newEntry(InfoLevel, msg)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.emit(e)
+}
+
+// span renders c as shaded text when the output is colored, in backticks otherwise
+func (n *Notifier) span(c Code) string {
+	if !n.colored() {
+		return "`" + string(c) + "`"
+	}
+	return paint(n.force(commandStyle), " "+string(c)+" ")
+}
+
+// Codef logs a message with inline code spans using the default Notifier
+// Commands and paths stand out from the prose around them
+func Codef(format string, args ...any) { Default.Codef(format, args...) }
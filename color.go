@@ -118,3 +118,20 @@ func (v Value) BlinkFast() Value       { return v.Colorize(color.BlinkRapid) }
 func (v Value) Reverse() Value         { return v.Colorize(color.ReverseVideo) }
 func (v Value) Conceal() Value         { return v.Colorize(color.Concealed) }
 func (v Value) Strike() Value          { return v.Colorize(color.CrossedOut) }
+
+// Style is a reusable set of color attributes
+// Used wherever a color applies to part of a message rather than a level
+type Style []color.Attribute
+
+// NewStyle creates a Style from color attributes
+// e.g. NewStyle(color.Bold, color.FgHiRed)
+func NewStyle(attrs ...color.Attribute) Style { return Style(attrs) }
+
+// Sprint renders s with the style's attributes
+// Honors color.NoColor like the rest of the package
+func (s Style) Sprint(text string) string {
+	if len(s) == 0 {
+		return text
+	}
+	return color.New(s...).Sprint(text)
+}
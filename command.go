@@ -0,0 +1,20 @@
+package aurora
+
+import "github.com/fatih/color"
+
+// commandStyle shades suggested commands like a code block
+var commandStyle = color.New(color.BgHiBlack, color.FgHiWhite)
+
+// Command prints a shell command the user should run next, e.g. a rollback
+// The line starts with "$ " on a shaded background so suggestions look the
+// same across tools; plain mirrors get the bare "$ command" line
+func (n *Notifier) Command(cmd string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	line := "  " + paint(n.force(commandStyle), " $ "+cmd+" ") + "\n"
+	n.output.route(AllSinks, []byte(n.colorMode.apply(line)), []byte("  $ "+cmd+"\n"))
+}
+
+// Command prints a suggested shell command using the default Notifier
+// Keeps "run this next" instructions visually consistent
+func Command(cmd string) { Default.Command(cmd) }
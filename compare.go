@@ -0,0 +1,68 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"math"
+	"strconv"
+)
+
+// Colors of the best and worst value in a Compare row
+var (
+	bestColor  = color.New(color.FgGreen, color.Bold)
+	worstColor = color.New(color.FgRed)
+)
+
+// Compare prints rows of measurements under headers with the best value
+// of each row in bold green and the worst in red, e.g. benchmark results
+// per implementation; rows whose values are all equal are left plain
+func (n *Notifier) Compare(headers []string, rows [][]float64, higherIsBetter bool) {
+	better := func(a, b float64) bool {
+		if higherIsBetter {
+			return a > b
+		}
+		return a < b
+	}
+	cells := make([][]string, len(rows))
+	best := make([]int, len(rows))
+	worst := make([]int, len(rows))
+	for r, row := range rows {
+		best[r], worst[r] = -1, -1
+		cells[r] = make([]string, len(row))
+		for c, v := range row {
+			cells[r][c] = strconv.FormatFloat(v, 'f', -1, 64)
+			if math.IsNaN(v) {
+				continue
+			}
+			if best[r] < 0 || better(v, row[best[r]]) {
+				best[r] = c
+			}
+			if worst[r] < 0 || better(row[worst[r]], v) {
+				worst[r] = c
+			}
+		}
+		if best[r] >= 0 && row[best[r]] == row[worst[r]] {
+			best[r], worst[r] = -1, -1
+		}
+	}
+
+	n.mu.Lock()
+	good, bad := n.force(bestColor), n.force(worstColor)
+	n.mu.Unlock()
+	n.writeBlock(renderTable(headers, cells, terminalWidth(), func(row, col int, cell string) string {
+		switch {
+		case row < 0:
+			return cell
+		case col == best[row]:
+			return paint(good, cell)
+		case col == worst[row]:
+			return paint(bad, cell)
+		}
+		return cell
+	}))
+}
+
+// Compare prints a comparison table using the default Notifier
+// Winners and losers of each row stand out
+func Compare(headers []string, rows [][]float64, higherIsBetter bool) {
+	Default.Compare(headers, rows, higherIsBetter)
+}
@@ -0,0 +1,35 @@
+package aurora
+
+import (
+	"encoding/base64"
+	"github.com/fatih/color"
+)
+
+// copyableColor styles values meant to be copied so they stand out from log text
+var copyableColor = color.New(color.FgHiCyan, color.Bold)
+
+// Copyable prints label and then value on a line of its own, easy to select
+// When the terminal supports OSC 52 the value is also put on the clipboard
+// and a dim "(copied to clipboard)" note follows; handy for tokens and commands
+func (n *Notifier) Copyable(label, value string) {
+	copied := n.Capabilities().Clipboard
+	n.Inlinef(InfoLevel, "%s:", label)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	line := "  " + paint(n.force(copyableColor), value) + "\n"
+	n.output.route(AllSinks, []byte(n.colorMode.apply(line)), []byte("  "+value+"\n"))
+	if copied {
+		note := "  " + paint(n.force(color.New(color.Faint)), tr("(copied to clipboard)")) + "\n"
+		n.output.route(ConsoleSink, []byte(osc52(value)+n.colorMode.apply(note)), nil)
+	}
+}
+
+// osc52 returns the escape sequence asking the terminal to set the clipboard
+func osc52(value string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(value)) + "\a"
+}
+
+// Copyable prints a value for copy-paste using the default Notifier
+// Also places it on the clipboard when the terminal allows
+func Copyable(label, value string) { Default.Copyable(label, value) }
@@ -0,0 +1,44 @@
+package aurora
+
+import (
+	"encoding/csv"
+	"github.com/fatih/color"
+	"io"
+	"strconv"
+)
+
+// CSV parses comma separated data from r and prints it as an aligned table
+// The first record is treated as the header and highlighted
+// Wide files are fitted to the terminal by truncating the widest columns
+func (n *Notifier) CSV(r io.Reader) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		n.Logf(ErrorLevel, tr("failed to parse CSV: %v"), err)
+		return
+	}
+	if len(records) == 0 {
+		n.Inlinef(DebugLevel, "%s", tr("CSV: no records"))
+		return
+	}
+
+	header, rows := records[0], records[1:]
+	headerColor := color.New(color.Bold, color.FgHiCyan)
+	numberColor := color.New(color.FgHiYellow)
+	block := renderTable(header, rows, terminalWidth(), func(row, col int, cell string) string {
+		if row < 0 {
+			return headerColor.Sprint(cell)
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil {
+			return numberColor.Sprint(cell)
+		}
+		return cell
+	})
+
+	n.writeBlock(block + color.New(color.Faint).Sprintf("(%d %s)\n", len(rows), plural(len(rows), "row", "rows")))
+}
+
+// CSV prints comma separated data as a table using the default Notifier
+// Handy for peeking at data pipeline output
+func CSV(r io.Reader) { Default.CSV(r) }
@@ -0,0 +1,120 @@
+package aurora
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces the values of redacted headers
+const redactedValue = "[REDACTED]"
+
+// maxCurlBody is the largest request body included in a curl command
+const maxCurlBody = 64 << 10
+
+// defaultRedactedHeaders are masked in curl commands unless WithRedactedHeaders says otherwise
+var defaultRedactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "X-Api-Key"}
+
+// Curl prints a single-line curl command replaying req, styled like Command
+// Credential headers are redacted by policy, see WithRedactedHeaders; the body is
+// included up to 64 KiB and left readable for the caller by buffering it
+func (n *Notifier) Curl(req *http.Request) {
+	n.Command(n.curlCommand(req))
+}
+
+// curlCommand builds the curl command line for req
+func (n *Notifier) curlCommand(req *http.Request) string {
+	redacted := n.redacted
+	if redacted == nil {
+		redacted = defaultRedactedHeaders
+	}
+
+	args := []string{"curl"}
+	if req.Method != "" && req.Method != http.MethodGet {
+		args = append(args, "-X", req.Method)
+	}
+	args = append(args, shellQuote(requestURL(req)))
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if slices.ContainsFunc(redacted, func(r string) bool { return strings.EqualFold(r, name) }) {
+				value = redactedValue
+			}
+			args = append(args, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	body, truncated := requestBody(req)
+	if len(body) > 0 {
+		args = append(args, "--data-raw", shellQuote(string(body)))
+	}
+	if truncated {
+		args = append(args, "# "+fmt.Sprintf(tr("body truncated at %s"), humanBytes(maxCurlBody)))
+	}
+	return strings.Join(args, " ")
+}
+
+// requestURL returns the absolute URL of req
+// Server requests only carry the path, so host and scheme are taken from
+// the Host header and the TLS state instead
+func requestURL(req *http.Request) string {
+	if req.URL.Host != "" || req.Host == "" {
+		return req.URL.String()
+	}
+	u := *req.URL
+	u.Host, u.Scheme = req.Host, "http"
+	if req.TLS != nil {
+		u.Scheme = "https"
+	}
+	return u.String()
+}
+
+// requestBody returns up to maxCurlBody bytes of the body of req without
+// consuming it and whether there was more. Uses GetBody when set, otherwise
+// reads the start of the body and puts it back in front of the rest
+func requestBody(req *http.Request) ([]byte, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, false
+	}
+	var r io.Reader = req.Body
+	copied := false
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			defer rc.Close()
+			r, copied = rc, true
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(r, maxCurlBody+1))
+	if !copied {
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+	}
+	if len(body) > maxCurlBody {
+		return body[:maxCurlBody], true
+	}
+	return body, false
+}
+
+// shellQuote wraps s in single quotes for POSIX shells when needed
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@,+%", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Curl prints a curl command replaying req using the default Notifier
+// Lets engineers replay a logged call
+func Curl(req *http.Request) { Default.Curl(req) }
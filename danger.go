@@ -0,0 +1,81 @@
+package aurora
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotConfirmed is returned by Danger when the typed phrase does not match
+var ErrNotConfirmed = errors.New("aurora: operation not confirmed")
+
+// dangerConfig holds the settings of a single Danger call
+type dangerConfig struct {
+	phrase string // Text the user has to type, "yes" by default
+	skip   bool   // Whether confirmation is assumed, for automation
+}
+
+// DangerOption configures a Danger confirmation
+type DangerOption func(*dangerConfig)
+
+// ConfirmPhrase sets the exact text the user has to type to proceed
+// e.g. ConfirmPhrase("delete prod")
+func ConfirmPhrase(phrase string) DangerOption {
+	return func(c *dangerConfig) { c.phrase = phrase }
+}
+
+// AssumeConfirmed skips the prompt when yes is true, e.g. for a --yes flag
+// The warning is still logged so automated runs leave a trace
+func AssumeConfirmed(yes bool) DangerOption {
+	return func(c *dangerConfig) { c.skip = yes }
+}
+
+// Danger shows msg in a red box and asks the user to type a confirmation phrase
+// Returns nil once confirmed and ErrNotConfirmed on any other answer
+// Read errors, e.g. no input available, are returned as they are
+func (n *Notifier) Danger(msg string, opts ...DangerOption) error {
+	cfg := dangerConfig{phrase: "yes"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.skip {
+		n.Warn(tr("%s (confirmation skipped)"), msg)
+		return nil
+	}
+
+	lines := []string{IconWarning + " " + msg, fmt.Sprintf(tr("Type %q to continue"), cfg.phrase)}
+	n.mu.Lock()
+	b := paint(n.color(ErrorLevel), box(lines))
+	n.output.route(ConsoleSink, []byte(n.colorMode.apply(b)), nil)
+	n.mu.Unlock()
+
+	answer, err := n.prompt("> ")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(answer) != cfg.phrase {
+		n.Failure(tr("confirmation did not match, aborted"))
+		return ErrNotConfirmed
+	}
+	return nil
+}
+
+// box draws a rounded frame around lines, padded to the widest one
+func box(lines []string) string {
+	width := 0
+	for _, line := range lines {
+		width = max(width, displayWidth(line))
+	}
+
+	var b strings.Builder
+	b.WriteString("╭" + strings.Repeat("─", width+2) + "╮\n")
+	for _, line := range lines {
+		b.WriteString("│ " + line + strings.Repeat(" ", width-displayWidth(line)) + " │\n")
+	}
+	b.WriteString("╰" + strings.Repeat("─", width+2) + "╯\n")
+	return b.String()
+}
+
+// Danger asks for typed confirmation using the default Notifier
+// Guards destructive operations in ops tools
+func Danger(msg string, opts ...DangerOption) error { return Default.Danger(msg, opts...) }
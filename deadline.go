@@ -0,0 +1,59 @@
+package aurora
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// deadlineThreshold is the remaining time below which Deadline starts warning
+// Adjusted through SetDeadlineThreshold and guarded by the package mutex
+var deadlineThreshold = 10 * time.Second
+
+// Deadline watches the deadline of ctx in the background
+// Logs a warning once less than the threshold remains and an error if it is exceeded
+// Returns immediately when ctx has no deadline; cancelling ctx stops the watch quietly
+func (n *Notifier) Deadline(ctx context.Context, name string) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+
+	mu.RLock()
+	threshold := deadlineThreshold
+	mu.RUnlock()
+
+	go n.watchDeadline(ctx, name, deadline, threshold)
+}
+
+// watchDeadline blocks until ctx is done, reporting deadline pressure on the way
+// Internal worker started by Deadline
+func (n *Notifier) watchDeadline(ctx context.Context, name string, deadline time.Time, threshold time.Duration) {
+	timer := time.NewTimer(time.Until(deadline) - threshold)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+		if remaining := time.Until(deadline); remaining > 0 {
+			n.Warn(tr("%s: %s left before deadline"), name, remaining.Round(time.Millisecond))
+		}
+	}
+
+	<-ctx.Done()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		n.Error(tr("%s: deadline exceeded"), name)
+	}
+}
+
+// Deadline watches a context deadline using the default Notifier
+// Quick way to diagnose timeout related failures
+func Deadline(ctx context.Context, name string) { Default.Deadline(ctx, name) }
+
+// SetDeadlineThreshold sets how close to a deadline warnings start
+// Applies to watches started after the call
+func SetDeadlineThreshold(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	deadlineThreshold = d
+}
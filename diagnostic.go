@@ -0,0 +1,158 @@
+package aurora
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/fatih/color"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Label colors of diagnostic notes and suggestions
+var (
+	noteColor = color.New(color.FgCyan)
+	helpColor = color.New(color.FgGreen)
+)
+
+// Diagnostic is a finding of a linter, validator or config parser
+// Severity is usually ErrorLevel or WarnLevel; Line and Col are 1-based
+// and zero when unknown
+type Diagnostic struct {
+	Severity    LogLevel
+	Message     string
+	File        string
+	Line        int
+	Col         int
+	Rule        string   // Identifier of the check, e.g. "unused-key"
+	Notes       []string // Extra context shown below the message
+	Suggestions []string // Possible fixes shown below the notes
+}
+
+// Location returns "file:line:col", leaving out unknown parts
+func (d Diagnostic) Location() string {
+	loc := d.File
+	if d.Line > 0 {
+		loc += ":" + strconv.Itoa(d.Line)
+		if d.Col > 0 {
+			loc += ":" + strconv.Itoa(d.Col)
+		}
+	}
+	return loc
+}
+
+// String renders the headline, e.g. "app.toml:3:8: unterminated string [syntax]"
+func (d Diagnostic) String() string {
+	s := d.Message
+	if loc := d.Location(); loc != "" {
+		s = loc + ": " + s
+	}
+	if d.Rule != "" {
+		s += " [" + d.Rule + "]"
+	}
+	return s
+}
+
+// Diagnostic prints d at its severity followed by the source excerpt,
+// notes and suggestions; the excerpt is left out when File is unreadable
+func (n *Notifier) Diagnostic(d Diagnostic) {
+	if !n.enabled(d.Severity) {
+		return
+	}
+	e := n.newEntry(d.Severity, d.String())
+	var src []byte
+	if d.File != "" && d.Line > 0 {
+		src, _ = os.ReadFile(d.File)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.emit(e)
+	var b strings.Builder
+	if src != nil {
+		b.WriteString(n.excerpt(string(src), d.Line, d.Col, 1))
+	}
+	for _, note := range d.Notes {
+		b.WriteString("  = " + paint(n.force(noteColor), tr("note")) + ": " + note + "\n")
+	}
+	for _, fix := range d.Suggestions {
+		b.WriteString("  = " + paint(n.force(helpColor), tr("help")) + ": " + fix + "\n")
+	}
+	if b.Len() > 0 {
+		fmt.Fprint(n.output, n.colorMode.apply(b.String()))
+	}
+}
+
+// DiagnosticBag collects diagnostics from concurrent checks
+// The zero value is ready to use
+type DiagnosticBag struct {
+	mu    sync.Mutex
+	items []Diagnostic
+}
+
+// Add records d; exact duplicates are dropped when the bag is read
+func (b *DiagnosticBag) Add(d Diagnostic) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.items = append(b.items, d)
+}
+
+// Diagnostics returns the findings sorted by file, line and column with
+// the most severe first at the same location and duplicates removed
+func (b *DiagnosticBag) Diagnostics() []Diagnostic {
+	b.mu.Lock()
+	items := slices.Clone(b.items)
+	b.mu.Unlock()
+
+	slices.SortStableFunc(items, func(x, y Diagnostic) int {
+		for _, c := range []int{
+			cmp.Compare(x.File, y.File),
+			cmp.Compare(x.Line, y.Line),
+			cmp.Compare(x.Col, y.Col),
+			cmp.Compare(y.Severity, x.Severity),
+		} {
+			if c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(x.String(), y.String())
+	})
+	return slices.CompactFunc(items, func(x, y Diagnostic) bool {
+		return x.Severity == y.Severity && x.String() == y.String()
+	})
+}
+
+// Summary counts the findings, e.g. "2 errors, 1 warning"
+func (b *DiagnosticBag) Summary() string {
+	var s Stats
+	s.Counts = make(map[LogLevel]int)
+	for _, d := range b.Diagnostics() {
+		s.Counts[d.Severity]++
+	}
+	return s.String()
+}
+
+// Diagnostics prints every finding of bag in order followed by a summary
+// at Error level after errors, Warn after warnings and Info otherwise
+func (n *Notifier) Diagnostics(bag *DiagnosticBag) {
+	items := bag.Diagnostics()
+	for _, d := range items {
+		n.Diagnostic(d)
+	}
+	level := InfoLevel
+	for _, d := range items {
+		switch {
+		case d.Severity >= ErrorLevel:
+			level = ErrorLevel
+		case d.Severity == WarnLevel && level < WarnLevel:
+			level = WarnLevel
+		}
+	}
+	if len(items) == 0 {
+		n.Inlinef(level, "%s", tr("no problems found"))
+		return
+	}
+	n.Inlinef(level, "%s", bag.Summary())
+}
@@ -0,0 +1,359 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Colors of removed and added lines and of the words changed within them
+var (
+	removedColor     = color.New(color.FgRed)
+	addedColor       = color.New(color.FgGreen)
+	removedWordColor = color.New(color.BgRed, color.FgHiWhite)
+	addedWordColor   = color.New(color.BgGreen, color.FgBlack)
+	hunkColor        = color.New(color.FgCyan)
+)
+
+// diffContext is the number of unchanged lines shown around a change
+const diffContext = 3
+
+// sideBySideMinWidth is the narrowest terminal SideBySide renders two columns in
+const sideBySideMinWidth = 80
+
+// diffOp is one step of an edit script: ' ' keeps, '-' removes, '+' adds
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// diffRow is one line of a rendered diff
+// Kind '~' pairs a removed line with the added line replacing it,
+// which is where changed words are highlighted
+type diffRow struct {
+	kind     byte
+	old, new string
+	oldLine  int
+	newLine  int
+}
+
+// Diff prints the line differences between before and after
+// Lines replaced by another line get the changed words highlighted,
+// so a single edited value in a config file is easy to spot
+func (n *Notifier) Diff(before, after string) {
+	n.writeBlock(n.renderDiff(before, after, 0))
+}
+
+// SideBySide prints the differences between before and after in two columns
+// Meant for wide terminals; narrower than 80 columns it prints like Diff
+func (n *Notifier) SideBySide(before, after string) {
+	n.writeBlock(n.renderDiff(before, after, terminalWidth()))
+}
+
+// renderDiff lays out the diff in unified form, or in two columns
+// when width leaves room for them
+func (n *Notifier) renderDiff(before, after string, width int) string {
+	rows := diffRows(diffTokens(diffLines(before), diffLines(after)))
+	hunks := diffHunks(rows)
+
+	n.mu.Lock()
+	removed, added := n.force(removedColor), n.force(addedColor)
+	removedWord, addedWord := n.force(removedWordColor), n.force(addedWordColor)
+	header, faint := n.force(hunkColor), n.force(color.New(color.Faint))
+	n.mu.Unlock()
+	if len(hunks) == 0 {
+		return paint(faint, tr("no differences")) + "\n"
+	}
+
+	column := 0
+	if width >= sideBySideMinWidth {
+		column = (width - 3) / 2
+	}
+	var b strings.Builder
+	for _, h := range hunks {
+		b.WriteString(paint(header, hunkHeader(rows[h[0]:h[1]])) + "\n")
+		for _, r := range rows[h[0]:h[1]] {
+			var left, right string
+			var lw int
+			switch r.kind {
+			case ' ':
+				left, lw = clipText("  "+r.old, column)
+				right, _ = clipText("  "+r.new, column)
+			case '-':
+				left, lw = clipText("- "+r.old, column)
+				left = paint(removed, left)
+			case '+':
+				right, _ = clipText("+ "+r.new, column)
+				right = paint(added, right)
+			case '~':
+				words := diffTokens(wordTokens(r.old), wordTokens(r.new))
+				left, lw = styleWords(words, '-', removed, removedWord, column)
+				right, _ = styleWords(words, '+', added, addedWord, column)
+			}
+			if column == 0 {
+				if r.kind != '+' {
+					b.WriteString(left + "\n")
+				}
+				if r.kind == '+' || r.kind == '~' {
+					b.WriteString(right + "\n")
+				}
+				continue
+			}
+			b.WriteString(left + strings.Repeat(" ", max(column-lw, 0)) + " │ " + right + "\n")
+		}
+	}
+	return b.String()
+}
+
+// diffLines splits s into lines, ignoring a trailing newline
+func diffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// wordTokens splits a line into words, runs of spaces and single punctuation
+// marks, the units changes within a line are highlighted in
+func wordTokens(s string) []string {
+	class := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		}
+		return 3
+	}
+	var tokens []string
+	start, prev := 0, 0
+	for i, r := range s {
+		c := class(r)
+		if i > start && (c != prev || c == 3) {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		prev = c
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+// diffTokens computes a shortest edit script from a to b
+// The common prefix and suffix are kept as they are and only the middle
+// goes through Myers' algorithm; removals come before additions
+func diffTokens(a, b []string) []diffOp {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	ops := make([]diffOp, 0, max(len(a), len(b)))
+	for _, t := range a[:pre] {
+		ops = append(ops, diffOp{' ', t})
+	}
+	ops = append(ops, myersDiff(a[pre:len(a)-suf], b[pre:len(b)-suf])...)
+	for _, t := range a[len(a)-suf:] {
+		ops = append(ops, diffOp{' ', t})
+	}
+	return ops
+}
+
+// myersDiff finds a shortest edit script in O((n+m)·d) time, d being the
+// number of edits; each step keeps only the diagonals it reached, so memory
+// grows with d² rather than with the product of the lengths
+func myersDiff(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	off := n + m + 1
+	v := make([]int, 2*off+1)
+	var trace [][]int
+	d := 0
+search:
+	for ; ; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := v[off+k-1] + 1
+			if k == -d || k != d && v[off+k-1] < v[off+k+1] {
+				x = v[off+k+1]
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+		trace = append(trace, slices.Clone(v[off-d:off+d+1]))
+	}
+
+	// Walk back from the end, one edit and the snake after it per step
+	var ops []diffOp
+	x, y := n, m
+	for ; d > 0; d-- {
+		prev := func(k int) int { return trace[d-1][k+d-1] }
+		k := x - y
+		pk := k - 1
+		if k == -d || k != d && prev(k-1) < prev(k+1) {
+			pk = k + 1
+		}
+		px := prev(pk)
+		py := px - pk
+		for x > px && y > py {
+			ops = append(ops, diffOp{' ', a[x-1]})
+			x, y = x-1, y-1
+		}
+		if pk == k+1 {
+			ops = append(ops, diffOp{'+', b[py]})
+		} else {
+			ops = append(ops, diffOp{'-', a[px]})
+		}
+		x, y = px, py
+	}
+	for ; x > 0; x-- {
+		ops = append(ops, diffOp{' ', a[x-1]})
+	}
+	slices.Reverse(ops)
+
+	// Adjacent edits may come in any order; put the removals of a run first,
+	// '-' sorting after '+' in ASCII
+	for i := 0; i < len(ops); {
+		j := i
+		for j < len(ops) && ops[j].kind != ' ' {
+			j++
+		}
+		slices.SortStableFunc(ops[i:j], func(p, q diffOp) int { return int(q.kind) - int(p.kind) })
+		i = j + 1
+	}
+	return ops
+}
+
+// diffRows turns a line edit script into rows, pairing each run of removed
+// lines with the run of added lines that follows it
+func diffRows(ops []diffOp) []diffRow {
+	var rows []diffRow
+	oldLine, newLine := 1, 1
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			rows = append(rows, diffRow{kind: ' ', old: ops[i].text, new: ops[i].text, oldLine: oldLine, newLine: newLine})
+			oldLine, newLine, i = oldLine+1, newLine+1, i+1
+			continue
+		}
+		var removed, added []string
+		for ; i < len(ops) && ops[i].kind == '-'; i++ {
+			removed = append(removed, ops[i].text)
+		}
+		for ; i < len(ops) && ops[i].kind == '+'; i++ {
+			added = append(added, ops[i].text)
+		}
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			r := diffRow{oldLine: oldLine, newLine: newLine}
+			switch {
+			case k < len(removed) && k < len(added):
+				r.kind, r.old, r.new = '~', removed[k], added[k]
+				oldLine, newLine = oldLine+1, newLine+1
+			case k < len(removed):
+				r.kind, r.old = '-', removed[k]
+				oldLine++
+			default:
+				r.kind, r.new = '+', added[k]
+				newLine++
+			}
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// diffHunks returns the row ranges holding changes and their context
+// Changes closer than twice the context share one hunk
+func diffHunks(rows []diffRow) [][2]int {
+	var hunks [][2]int
+	for i, r := range rows {
+		if r.kind == ' ' {
+			continue
+		}
+		start, end := max(i-diffContext, 0), min(i+diffContext+1, len(rows))
+		if k := len(hunks) - 1; k >= 0 && start <= hunks[k][1] {
+			hunks[k][1] = end
+			continue
+		}
+		hunks = append(hunks, [2]int{start, end})
+	}
+	return hunks
+}
+
+// hunkHeader describes the lines a hunk covers, e.g. "@@ -3,7 +3,8 @@"
+func hunkHeader(rows []diffRow) string {
+	var oldCount, newCount int
+	for _, r := range rows {
+		if r.kind != '+' {
+			oldCount++
+		}
+		if r.kind != '-' {
+			newCount++
+		}
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", rows[0].oldLine, oldCount, rows[0].newLine, newCount)
+}
+
+// styleWords renders one side of a changed line from its word edit script
+// Words kept on both sides get base, words only on this side get word;
+// a width above zero clips the line, and the visible width is returned
+func styleWords(ops []diffOp, side byte, base, word *color.Color, width int) (string, int) {
+	var b strings.Builder
+	mark := "- "
+	if side == '+' {
+		mark = "+ "
+	}
+	b.WriteString(paint(base, mark))
+	used := 2
+	for _, op := range ops {
+		if op.kind != ' ' && op.kind != side {
+			continue
+		}
+		text, w := op.text, displayWidth(op.text)
+		if width > 0 && used+w > width {
+			text, w = clipText(text, width-used)
+			if w == 0 {
+				text, w = "…", 1
+			}
+			used = width
+		} else {
+			used += w
+		}
+		if op.kind == side {
+			b.WriteString(paint(word, text))
+		} else {
+			b.WriteString(paint(base, text))
+		}
+		if used == width {
+			break
+		}
+	}
+	return b.String(), used
+}
+
+// clipText shortens s to width columns unless width is zero
+// Returns the text and its visible width
+func clipText(s string, width int) (string, int) {
+	if width > 0 {
+		s = truncate(s, width)
+	}
+	return s, displayWidth(s)
+}
+
+// Diff prints the differences between two texts using the default Notifier
+// Changed words within a line are highlighted
+func Diff(before, after string) { Default.Diff(before, after) }
+
+// SideBySide prints a two-column diff using the default Notifier
+// Best on wide terminals
+func SideBySide(before, after string) { Default.SideBySide(before, after) }
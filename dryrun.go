@@ -0,0 +1,79 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"os/exec"
+	"strings"
+)
+
+// dryRunTag marks action messages while dry-run mode is on
+const dryRunTag = "[dry-run]"
+
+// dryRunColor styles the dry-run tag so it stands apart from the level color
+var dryRunColor = color.New(color.FgHiYellow, color.Bold)
+
+// DryRun sets whether actions are only announced instead of performed
+// Success, Step and Exec messages get a "[dry-run]" tag and Exec skips the command
+// Applies to Notifiers derived afterwards as well
+func (n *Notifier) DryRun(enabled bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.dryRun = enabled
+}
+
+// Step announces an action the program is about to take
+// Shown with an arrow at Info level, e.g. "→ creating bucket"
+func (n *Notifier) Step(format string, args ...any) {
+	n.action(InfoLevel, n.f(IconStep, " ", format), args...)
+}
+
+// Exec announces cmd as a step and runs it, streaming its output as plain lines
+// Output the caller already redirected is left alone
+// In dry-run mode cmd is not started and Exec reports success
+func (n *Notifier) Exec(cmd *exec.Cmd) error {
+	n.action(InfoLevel, "%s", "$ "+strings.Join(cmd.Args, " "))
+	n.mu.Lock()
+	dryRun := n.dryRun
+	n.mu.Unlock()
+	if dryRun {
+		return nil
+	}
+
+	out := newLineWriter(func(line string) { n.Printf(NoLevel, "%s", line) })
+	defer out.Close()
+	if cmd.Stdout == nil {
+		cmd.Stdout = out
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = out
+	}
+	return cmd.Run()
+}
+
+// action writes an action message, tagged when dry-run mode is on
+// Internal helper behind Success, Step and Exec
+func (n *Notifier) action(level LogLevel, format string, args ...any) {
+	if !n.enabled(level) {
+		return
+	}
+	e := n.newEntry(level, sprintf(format, args))
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.dryRun {
+		e.Tag, e.tagColor = dryRunTag, dryRunColor
+	}
+	n.emit(e)
+}
+
+// DryRun sets dry-run mode on the default Notifier
+// Lets a whole CLI honor a --dry-run flag in one place
+func DryRun(enabled bool) { Default.DryRun(enabled) }
+
+// Exec announces and runs cmd using the default Notifier
+// Skipped with simulated success in dry-run mode
+func Exec(cmd *exec.Cmd) error { return Default.Exec(cmd) }
+
+// Step announces an action using the default Notifier
+// Pairs with Success to narrate multi-step operations
+func Step(format string, args ...any) { Default.Step(format, args...) }
@@ -0,0 +1,387 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/fatih/color"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a log entry before it is formatted
+// Hooks and sinks can reshape it without parsing the rendered text
+type Entry struct {
+	Time    time.Time // Creation time of the entry
+	Level   LogLevel  // Severity, NoLevel for plain lines
+	Prefix  string    // Prefixes added with With, space separated
+	Caller  string    // "file:line" of the logging call when caller reporting is on
+	Worker  string    // Worker or goroutine label, see WithWorker and WithGoroutineID
+	Tag     string    // Label shown before the message, e.g. "[dry-run]"
+	Message string    // Formatted user message
+	Fields  []Field   // Fields from WithFields, shared; copy before changing
+}
+
+// MarshalJSON encodes the entry in the shape of WithJSONFormat output
+// Field values JSON cannot encode are written as their fmt.Sprint text
+func (e Entry) MarshalJSON() ([]byte, error) {
+	je := jsonEntry{
+		Time:   e.Time.Format(time.RFC3339Nano),
+		Level:  e.Level.String(),
+		Prefix: e.Prefix,
+		Caller: e.Caller,
+		Worker: e.Worker,
+		Tag:    e.Tag,
+		Msg:    e.Message,
+		Fields: jsonFields(e.Fields),
+	}
+	data, err := json.Marshal(je)
+	if err != nil && len(e.Fields) > 0 {
+		je.Fields = jsonFields(printableFields(e.Fields))
+		data, err = json.Marshal(je)
+	}
+	return data, err
+}
+
+// MarshalText renders the entry as a single plain line without color
+// e.g. "2025-03-25T13:23:45Z [WARN] [api] disk low"
+func (e Entry) MarshalText() ([]byte, error) {
+	parts := []string{e.Time.Format(time.RFC3339)}
+	if e.Level != NoLevel {
+		parts = append(parts, "["+levelName(e.Level)+"]")
+	}
+	if e.Prefix != "" {
+		parts = append(parts, "["+e.Prefix+"]")
+	}
+	for _, part := range []string{e.Caller, e.Worker, e.Tag} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	parts = append(parts, StripANSI(e.Message))
+	if len(e.Fields) > 0 {
+		parts = append(parts, formatFields(e.Fields))
+	}
+	return []byte(strings.Join(parts, " ")), nil
+}
+
+// ToMap returns the non-empty fields keyed like the JSON form
+// Handy for sinks feeding structured stores
+func (e Entry) ToMap() map[string]any {
+	m := map[string]any{"time": e.Time, "level": e.Level.String(), "msg": e.Message}
+	for key, value := range map[string]string{"prefix": e.Prefix, "caller": e.Caller, "worker": e.Worker, "tag": e.Tag} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	if fields := fieldMap(e.Fields); fields != nil {
+		m["fields"] = fields
+	}
+	return m
+}
+
+// slogLevels maps aurora levels onto log/slog levels
+// Trace sits below Debug; Notice, Alert and Critical between and above the slog levels
+var slogLevels = map[LogLevel]slog.Level{
+	TraceLevel:    slog.LevelDebug - 4,
+	DebugLevel:    slog.LevelDebug,
+	InfoLevel:     slog.LevelInfo,
+	NoticeLevel:   slog.LevelInfo + 2,
+	WarnLevel:     slog.LevelWarn,
+	ErrorLevel:    slog.LevelError,
+	AlertLevel:    slog.LevelError + 2,
+	CriticalLevel: slog.LevelError + 4,
+}
+
+// ToSlogRecord converts the entry for log/slog handlers
+// Prefix, caller, worker, tag and fields become attributes when set
+func (e Entry) ToSlogRecord() slog.Record {
+	r := slog.NewRecord(e.Time, slogLevels[e.Level], e.Message, 0)
+	for key, value := range map[string]string{"prefix": e.Prefix, "caller": e.Caller, "worker": e.Worker, "tag": e.Tag} {
+		if value != "" {
+			r.AddAttrs(slog.String(key, value))
+		}
+	}
+	for _, f := range e.Fields {
+		r.AddAttrs(slog.Any(f.Key, f.Value))
+	}
+	return r
+}
+
+// entry is a single log line on its way from a logging call to the output
+// The public parts live in Entry; the rest steers the text rendering
+type entry struct {
+	Entry
+	stamped  bool         // Whether the text form shows the timestamp (Logf)
+	plain    bool         // Whether the text form omits the symbol (Printf)
+	sinks    SinkTag      // Destinations of the entry, every sink when zero
+	fleeting bool         // Whether a terminal clears the entry once superseded
+	tagColor *color.Color // Color of the tag, independent of the level color
+	color    *color.Color // Color replacing the level color, nil for the default
+}
+
+// jsonEntry is the shape of an entry written in JSON format
+type jsonEntry struct {
+	Time   string `json:"time"`
+	Level  string `json:"level"`
+	Prefix string `json:"prefix,omitempty"`
+	Caller string `json:"caller,omitempty"`
+	Worker string `json:"worker,omitempty"`
+	Tag    string `json:"tag,omitempty"`
+	Msg    string `json:"msg"`
+	Fields any    `json:"fields,omitempty"`
+}
+
+// newEntry creates an entry for msg at level stamped with the current time
+// Resolves the caller when WithCaller is enabled; call without the mutex held
+func (n *Notifier) newEntry(level LogLevel, msg string) entry {
+	e := entry{Entry: Entry{Time: n.now(), Level: n.mapped(level), Prefix: n.prefix, Message: msg, Fields: n.fields}}
+	if n.caller {
+		e.Caller = callerOutsidePackage()
+	}
+	if n.worker != "" {
+		e.Worker = n.worker
+	} else if n.goroutineIDs {
+		e.Worker = "g" + strconv.FormatUint(goroutineID(), 10)
+	}
+	return e
+}
+
+// emit runs a single entry through middleware and writes what passes the filters
+// Internal helper; callers must hold the mutex
+func (n *Notifier) emit(e entry) {
+	if n.output.closed {
+		misuse("%q logged after Close", e.Message)
+	}
+	if len(n.middleware) > 0 {
+		for _, out := range n.through(e) {
+			n.admit(out)
+		}
+		return
+	}
+	n.admit(e)
+}
+
+// admit writes e unless it is filtered out, folded or escalated
+// Internal helper; callers must hold the mutex
+func (n *Notifier) admit(e entry) {
+	if !n.allowed(e.Message) {
+		n.dropped.count++
+		n.stats.dropped++
+		return
+	}
+	defer n.escalate(e)
+	if n.fold(e) {
+		return
+	}
+	n.write(e)
+}
+
+// bufferPool recycles the buffers entries are rendered into
+// so the hot path does not allocate a new line for every entry
+var bufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// maxPooledBuffer keeps buffers grown by huge entries out of the pool
+const maxPooledBuffer = 64 << 10
+
+// write renders and writes an entry without consulting filters
+// Highlight rules are applied to the message; NoLevel lines get no level color
+// Internal helper; callers must hold the mutex
+func (n *Notifier) write(e entry) {
+	n.stats.record(e)
+	if n.jsonFormat {
+		n.output.deliver(e)
+		n.writeJSONEntry(e)
+		return
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			bufferPool.Put(buf)
+		}
+	}()
+
+	lead := n.lead(e)
+	c := n.color(e.Level)
+	if e.color != nil {
+		c = n.force(e.color)
+	}
+	if n.colorMode.strips() {
+		c = nil // Skips painting what apply would strip again
+	}
+	switch {
+	case n.prefixHue && e.Prefix != "":
+		buf.WriteString(n.paintLead(c, lead, e.Prefix))
+		buf.WriteString(n.tagged(e))
+		buf.WriteString(n.highlight(c, e.Message))
+	case len(n.highlights) == 0 && e.Tag == "":
+		if c == nil {
+			buf.WriteString(lead)
+			buf.WriteString(e.Message)
+		} else {
+			buf.WriteString(paint(c, lead+e.Message))
+		}
+	default:
+		buf.WriteString(paint(c, lead))
+		buf.WriteString(n.tagged(e))
+		buf.WriteString(n.highlight(c, e.Message))
+	}
+	if len(e.Fields) > 0 {
+		buf.WriteByte(' ')
+		buf.WriteString(paint(n.force(fieldColor), formatFields(e.Fields)))
+	}
+	if e.fleeting && n.output.terminal() {
+		live := n.output.live
+		if live.fleeting != nil {
+			live.remove(live.fleeting)
+		}
+		live.fleeting = &liveLine{}
+		live.set(live.fleeting, n.colorMode.apply(buf.String()))
+		return
+	}
+	buf.WriteByte('\n')
+	n.output.deliver(e)
+
+	line := buf.Bytes()
+	if n.colorMode.strips() && bytes.IndexByte(line, '\x1b') >= 0 {
+		line = []byte(StripANSI(string(line)))
+	}
+	var plain []byte
+	if n.output.mirrored() {
+		plain = []byte(n.plainLine(e))
+	}
+	n.output.routeLevel(e.Level, e.destinations(), line, plain)
+}
+
+// lead renders everything before the message: symbol, timestamp, prefix and caller
+// Internal helper; callers must hold the mutex
+func (n *Notifier) lead(e entry) string {
+	var lead string
+	if e.plain {
+		if e.Prefix != "" {
+			lead = "[" + e.Prefix + "] "
+		}
+	} else {
+		narrow := compact() || Porcelain()
+		lead = n.compose(n.head(e, narrow), e.Prefix)
+		if narrow || n.symbolPos == SymbolHidden {
+			lead = strings.TrimPrefix(lead, " ")
+		}
+	}
+	if e.Caller != "" {
+		lead += e.Caller + " "
+	}
+	if e.Worker != "" {
+		lead += e.Worker + " "
+	}
+	return lead
+}
+
+// tagged returns the colored tag of e followed by a space, or "" without one
+func (n *Notifier) tagged(e entry) string {
+	if e.Tag == "" {
+		return ""
+	}
+	return paint(n.force(e.tagColor), e.Tag) + " "
+}
+
+// plainLine renders e for plain mirrors without color or custom symbols
+// The symbol is replaced by the level name, e.g. "[WARN]", and
+// NoLevel or Printf entries carry no level at all
+func (n *Notifier) plainLine(e entry) string {
+	var parts []string
+	if !e.plain && e.Level != NoLevel {
+		parts = append(parts, "["+levelName(e.Level)+"]")
+	}
+	if e.stamped {
+		parts = append(parts, n.timestamp(e.Time))
+	}
+	if e.Prefix != "" {
+		parts = append(parts, "["+e.Prefix+"]")
+	}
+	if e.Caller != "" {
+		parts = append(parts, e.Caller)
+	}
+	if e.Worker != "" {
+		parts = append(parts, e.Worker)
+	}
+	if e.Tag != "" {
+		parts = append(parts, e.Tag)
+	}
+	parts = append(parts, StripANSI(e.Message))
+	if len(e.Fields) > 0 {
+		parts = append(parts, formatFields(e.Fields))
+	}
+	return strings.Join(parts, " ") + "\n"
+}
+
+// destinations returns the sinks the entry is routed to
+func (e entry) destinations() SinkTag {
+	if e.sinks == 0 {
+		return AllSinks
+	}
+	return e.sinks
+}
+
+// writeJSONEntry writes e as a single line JSON object
+// Internal helper; callers must hold the mutex
+func (n *Notifier) writeJSONEntry(e entry) {
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return
+	}
+	n.output.routeLevel(e.Level, e.destinations(), append(data, '\n'), nil)
+}
+
+// compose joins the entry head (symbol, timestamp) and prefix into the lead
+// Pads the lead to the shared column when alignment is enabled
+// Internal helper; callers must hold the mutex
+func (n *Notifier) compose(head, prefix string) string {
+	var lead string
+	if prefix != "" {
+		lead = head + " [" + prefix + "] "
+	} else {
+		lead = head + " "
+	}
+	if n.align.enabled {
+		if w := displayWidth(lead); w > n.align.width {
+			n.align.width = w
+		} else {
+			lead += strings.Repeat(" ", n.align.width-w)
+		}
+	}
+	return lead
+}
+
+// packagePrefix identifies frames belonging to aurora itself
+var packagePrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndex(name, "/")
+	return name[:slash+strings.Index(name[slash:], ".")+1]
+}()
+
+// callerOutsidePackage returns "file:line" of the first frame outside aurora
+// Frames from aurora test files count as outside so tests see themselves
+func callerOutsidePackage() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		inPackage := strings.HasPrefix(frame.Function, packagePrefix) &&
+			!strings.Contains(frame.Function[len(packagePrefix):], "/") &&
+			!strings.HasSuffix(frame.File, "_test.go")
+		if !inPackage {
+			return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
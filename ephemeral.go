@@ -0,0 +1,24 @@
+package aurora
+
+// Ephemeral writes a message that disappears once superseded
+// On a terminal the line sits in the live region and is erased by the next
+// output, e.g. "waiting for lock…" vanishing once the lock is acquired
+// Other writers get a normal entry; file sinks never see the message
+func (n *Notifier) Ephemeral(level LogLevel, format string, args ...any) {
+	if !n.enabled(level) {
+		return
+	}
+	e := n.newEntry(level, sprintf(format, args))
+	e.fleeting = true
+	e.sinks = ConsoleSink
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.emit(e)
+}
+
+// Ephemeral writes a self-clearing message using the default Notifier
+// Transient status without clutter
+func Ephemeral(level LogLevel, format string, args ...any) {
+	Default.Ephemeral(level, format, args...)
+}
@@ -0,0 +1,88 @@
+package aurora
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// escalationRule raises an entry repeated too often to a summary
+type escalationRule struct {
+	level  LogLevel      // Level of the watched entries
+	count  int           // Repeats within window that trigger the summary
+	window time.Duration // Sliding window the repeats are counted in
+	to     LogLevel      // Level of the summary entry
+}
+
+// repeatID identifies the repeats of one entry counted by one rule
+type repeatID struct {
+	rule escalationRule
+	key  string
+}
+
+// repeatLog remembers when recent entries were written
+// Shared between derived Notifiers and guarded by their mutex
+type repeatLog struct {
+	times map[repeatID][]time.Time
+}
+
+// newRepeatLog creates an empty repeatLog
+func newRepeatLog() *repeatLog {
+	return &repeatLog{times: make(map[repeatID][]time.Time)}
+}
+
+// prune forgets repeats whose latest write left the window of their rule
+func (r *repeatLog) prune(now time.Time) {
+	for id, times := range r.times {
+		if now.Sub(times[len(times)-1]) > id.rule.window {
+			delete(r.times, id)
+		}
+	}
+}
+
+// Escalate writes a summary at level to once an entry at level repeats
+// more than count times within window, e.g. a Critical entry for a Warn
+// that fired 10 times in a minute. Repeats match like burst folding,
+// ignoring digits; the count restarts after each summary
+func (n *Notifier) Escalate(level LogLevel, count int, window time.Duration, to LogLevel) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	rule := escalationRule{level: level, count: count, window: window, to: to}
+	n.escalation = append(slices.Clip(n.escalation), rule)
+}
+
+// escalate records e and writes a summary when a rule triggers
+// Internal helper; callers must hold the mutex
+func (n *Notifier) escalate(e entry) {
+	if len(n.escalation) == 0 {
+		return
+	}
+	n.seen.prune(e.Time)
+	key := n.repeatKey(e)
+	for _, rule := range n.escalation {
+		if rule.level != e.Level {
+			continue
+		}
+		id := repeatID{rule: rule, key: key}
+		times := append(n.seen.times[id], e.Time)
+		cut := 0
+		for cut < len(times) && e.Time.Sub(times[cut]) > rule.window {
+			cut++
+		}
+		times = times[cut:]
+		if len(times) <= rule.count {
+			n.seen.times[id] = times
+			continue
+		}
+		delete(n.seen.times, id)
+		msg := fmt.Sprintf(tr("%q logged %d times within %s"), e.Message, len(times), rule.window)
+		n.write(n.newEntry(rule.to, msg))
+		return
+	}
+}
+
+// Escalate adds an escalation rule to the default Notifier
+// Surfaces systemic problems hidden in warning noise
+func Escalate(level LogLevel, count int, window time.Duration, to LogLevel) {
+	Default.Escalate(level, count, window, to)
+}
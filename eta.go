@@ -0,0 +1,145 @@
+package aurora
+
+import (
+	"fmt"
+	"time"
+)
+
+// Estimator turns progress observations into a rate for ETA calculation
+// Implementations need not be safe for concurrent use; Progress serializes calls
+type Estimator interface {
+	Observe(t time.Time, current int64) // Records the position at time t
+	Rate() float64                      // Returns units per second, 0 when unknown
+}
+
+// ewmaSampleInterval is the minimum time between EWMA samples
+// Shorter gaps make the instantaneous rate too noisy to be useful
+const ewmaSampleInterval = 200 * time.Millisecond
+
+// ewma is an exponentially weighted moving average of the rate
+type ewma struct {
+	alpha float64
+	rate  float64
+	last  time.Time
+	pos   int64
+}
+
+// EWMA estimates the rate with an exponentially weighted moving average
+// alpha in (0, 1] sets how strongly new samples count; 0.3 is a good default
+// Reacts quickly to speed changes, e.g. for downloads
+func EWMA(alpha float64) Estimator {
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.3
+	}
+	return &ewma{alpha: alpha}
+}
+
+// Observe folds the rate since the previous sample into the average
+func (e *ewma) Observe(t time.Time, current int64) {
+	if e.last.IsZero() {
+		e.last, e.pos = t, current
+		return
+	}
+	dt := t.Sub(e.last)
+	if dt < ewmaSampleInterval {
+		return
+	}
+	instant := float64(current-e.pos) / dt.Seconds()
+	if e.rate == 0 {
+		e.rate = instant
+	} else {
+		e.rate = e.alpha*instant + (1-e.alpha)*e.rate
+	}
+	e.last, e.pos = t, current
+}
+
+// Rate returns the smoothed rate
+func (e *ewma) Rate() float64 { return e.rate }
+
+// sample is a single position observation
+type sample struct {
+	t   time.Time
+	pos int64
+}
+
+// window averages the rate over a sliding time window
+type window struct {
+	span    time.Duration
+	samples []sample
+}
+
+// WindowAverage estimates the rate over the most recent span of time
+// Steadier than EWMA for batch jobs with uneven item costs
+func WindowAverage(span time.Duration) Estimator {
+	if span <= 0 {
+		span = 10 * time.Second
+	}
+	return &window{span: span}
+}
+
+// Observe records the position and drops samples older than the window
+func (w *window) Observe(t time.Time, current int64) {
+	w.samples = append(w.samples, sample{t, current})
+	cut := 0
+	for cut < len(w.samples)-2 && t.Sub(w.samples[cut].t) > w.span {
+		cut++
+	}
+	w.samples = w.samples[cut:]
+}
+
+// Rate returns the average rate across the window
+func (w *window) Rate() float64 {
+	if len(w.samples) < 2 {
+		return 0
+	}
+	first, last := w.samples[0], w.samples[len(w.samples)-1]
+	dt := last.t.Sub(first.t).Seconds()
+	if dt <= 0 {
+		return 0
+	}
+	return float64(last.pos-first.pos) / dt
+}
+
+// Unit formats the quantities tracked by a Progress
+type Unit struct {
+	Name   string             // Unit name shown after amounts, e.g. "items"
+	Format func(int64) string // Formats an amount; nil prints the number and Name
+}
+
+// Built-in progress units
+var (
+	UnitBytes = Unit{Format: func(v int64) string { return humanBytes(int(v)) }}
+	UnitItems = Unit{Name: "items"}
+)
+
+// CustomUnit returns a unit displaying amounts followed by name
+// e.g. CustomUnit("rows") renders "42 rows" and "12 rows/s"
+func CustomUnit(name string) Unit {
+	return Unit{Name: name}
+}
+
+// amount renders v in the unit
+func (u Unit) amount(v int64) string {
+	if u.Format != nil {
+		return u.Format(v)
+	}
+	if u.Name == "" {
+		return fmt.Sprint(v)
+	}
+	return fmt.Sprintf("%d %s", v, u.Name)
+}
+
+// rate renders a per second rate in the unit, e.g. "12.3 MB/s"
+func (u Unit) rate(r float64) string {
+	if u.Format != nil {
+		return u.Format(int64(r)) + "/s"
+	}
+	value := fmt.Sprintf("%.1f", r)
+	if r >= 10 {
+		value = fmt.Sprintf("%.0f", r)
+	}
+	if u.Name == "" {
+		return value + "/s"
+	}
+	return value + " " + u.Name + "/s"
+}
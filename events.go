@@ -0,0 +1,76 @@
+package aurora
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// SetEvents writes a JSON event stream to w alongside the human output
+// One object per line describes each entry ("log"), started and finished
+// steps ("start", "result") and progress ("progress"); nil turns it off
+// Applies to the Notifier and those derived from it
+func (n *Notifier) SetEvents(w io.Writer) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.events = nil
+	if w != nil {
+		n.output.events = json.NewEncoder(w)
+	}
+}
+
+// event writes an event of kind with data to the stream, if there is one
+// Internal helper; callers must hold the mutex
+func (s *switchWriter) event(kind string, data map[string]any) {
+	if s.events == nil {
+		return
+	}
+	data["type"] = kind
+	if _, ok := data["time"]; !ok {
+		data["time"] = time.Now()
+	}
+	s.events.Encode(data)
+}
+
+// started announces a step on the event stream
+func (n *Notifier) started(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.event("start", map[string]any{"name": name})
+}
+
+// resultEvent describes a finished step for the event stream
+func resultEvent(r stepResult) map[string]any {
+	data := map[string]any{"name": r.name, "status": "pass", "duration_ms": r.took.Milliseconds()}
+	switch {
+	case r.skipped:
+		data["status"] = "skip"
+	case r.err != nil:
+		data["status"], data["error"] = "fail", r.err.Error()
+	}
+	return data
+}
+
+// announce puts the position of p on the event stream in percent steps
+// Completion and unknown totals are only announced once done;
+// callers must hold p.mu
+func (p *Progress) announce(final bool) {
+	if !final {
+		if p.total <= 0 {
+			return
+		}
+		step := int(p.current*100/p.total) / progressStep * progressStep
+		if step <= p.announced || step >= 100 {
+			return
+		}
+		p.announced = step
+	}
+	n := p.n
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.event("progress", map[string]any{"name": p.label, "current": p.current, "total": p.total, "done": final})
+}
+
+// SetEvents writes a JSON event stream of the default Notifier to w
+// Lets wrappers script a tool while people read the console
+func SetEvents(w io.Writer) { Default.SetEvents(w) }
@@ -0,0 +1,73 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Excerpt prints the lines of path around line with a caret under col
+// Compiler-style context for config parsers and linters; line and col are
+// 1-based and col 0 omits the caret; returns an error if path is unreadable
+func (n *Notifier) Excerpt(path string, line, col, context int) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	gutter := paint(n.force(color.New(color.Faint)), "  --> ")
+	block := gutter + fmt.Sprintf("%s:%d:%d\n", path, line, col) + n.excerpt(string(data), line, col, context)
+	n.mu.Unlock()
+	n.writeBlock(block)
+	return nil
+}
+
+// excerpt renders the numbered lines of src within context of line
+// The caret is painted in the Error color; callers must hold the mutex
+func (n *Notifier) excerpt(src string, line, col, context int) string {
+	lines := strings.Split(strings.TrimSuffix(src, "\n"), "\n")
+	first, last := max(1, line-context), min(len(lines), line+context)
+	width := len(strconv.Itoa(last))
+	faint := n.force(color.New(color.Faint))
+
+	var b strings.Builder
+	for i := first; i <= last; i++ {
+		number := fmt.Sprintf("%*d │ ", width, i)
+		if i == line {
+			b.WriteString(number)
+		} else {
+			b.WriteString(paint(faint, number))
+		}
+		b.WriteString(lines[i-1] + "\n")
+		if i == line && col > 0 {
+			b.WriteString(paint(faint, strings.Repeat(" ", width)+" │ "))
+			b.WriteString(caretPad(lines[i-1], col) + paint(n.color(ErrorLevel), "^") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// caretPad returns the indentation placing a caret under column col of s
+// Tabs are kept so the caret lines up however wide the terminal shows them
+func caretPad(s string, col int) string {
+	var b strings.Builder
+	for i, r := range []rune(s) {
+		if i >= col-1 {
+			break
+		}
+		if r == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteString(strings.Repeat(" ", displayWidth(string(r))))
+		}
+	}
+	return b.String()
+}
+
+// Excerpt prints lines around a location using the default Notifier
+// Points at the offending column of a config or source file
+func Excerpt(path string, line, col, context int) error {
+	return Default.Excerpt(path, line, col, context)
+}
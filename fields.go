@@ -0,0 +1,146 @@
+package aurora
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/fatih/color"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fieldColor dims fields so the message stays the focus of the line
+var fieldColor = color.New(color.Faint)
+
+// Field is a key/value pair attached to entries
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Fields maps keys to values for WithFields
+type Fields map[string]any
+
+// WithFields returns a derived Notifier attaching fields to every entry
+// Inherited keys set again keep their position with the new value;
+// new keys follow the inherited ones in sorted order
+func (n *Notifier) WithFields(fields Fields) *Notifier {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	child := n.derive()
+	child.fields = slices.Clone(n.fields)
+	for _, key := range keys {
+		i := slices.IndexFunc(child.fields, func(f Field) bool { return f.Key == key })
+		if i >= 0 {
+			child.fields[i].Value = fields[key]
+		} else {
+			child.fields = append(child.fields, Field{Key: key, Value: fields[key]})
+		}
+	}
+	return child
+}
+
+// Without returns a derived Notifier that drops the inherited fields keys
+// e.g. a request logger hiding the service version of its parent
+func (n *Notifier) Without(keys ...string) *Notifier {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	child := n.derive()
+	child.fields = slices.DeleteFunc(slices.Clone(n.fields), func(f Field) bool {
+		return slices.Contains(keys, f.Key)
+	})
+	return child
+}
+
+// WithService stamps entries with host, pid, service and version fields
+// Makes lines from many hosts attributable once aggregated; an empty
+// version is left out, as is the host when it cannot be determined
+func WithService(name, version string) Option {
+	return func(n *Notifier) {
+		var meta []Field
+		if host, err := os.Hostname(); err == nil {
+			meta = append(meta, Field{Key: "host", Value: host})
+		}
+		meta = append(meta, Field{Key: "pid", Value: os.Getpid()}, Field{Key: "service", Value: name})
+		if version != "" {
+			meta = append(meta, Field{Key: "version", Value: version})
+		}
+		n.fields = append(slices.Clip(n.fields), meta...)
+	}
+}
+
+// formatFields renders fields as space separated key=value pairs
+// Values that are empty or contain spaces, newlines, quotes or '=' are quoted
+func formatFields(fields []Field) string {
+	var b strings.Builder
+	for i, f := range arrangeFields(fields) {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		value := fmt.Sprint(f.Value)
+		if value == "" || strings.ContainsAny(value, " =\"\n\r\t") {
+			value = strconv.Quote(value)
+		}
+		b.WriteString(f.Key)
+		b.WriteByte('=')
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+// printableFields returns a copy of fields in which values JSON cannot
+// encode, e.g. funcs, channels, NaN or cycles, are replaced by their text
+func printableFields(fields []Field) []Field {
+	printable := slices.Clone(fields)
+	for i, f := range printable {
+		if _, err := json.Marshal(f.Value); err != nil {
+			printable[i].Value = fmt.Sprint(f.Value)
+		}
+	}
+	return printable
+}
+
+// jsonFields returns fields for a JSON entry, nil without fields
+// A map unless SetKeyOrder asks for an order maps cannot keep
+func jsonFields(fields []Field) any {
+	fields = arrangeFields(fields)
+	switch {
+	case len(fields) == 0:
+		return nil
+	case currentKeyOrder().natural():
+		return fieldMap(fields)
+	}
+	obj := make(orderedObject, len(fields))
+	for i, f := range fields {
+		obj[i] = member{key: f.Key, value: f.Value}
+	}
+	return obj
+}
+
+// fieldMap returns fields keyed by name, nil without fields
+func fieldMap(fields []Field) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+// WithFields returns a Notifier derived from the default one with fields
+// Service-wide context such as a version or region
+func WithFields(fields Fields) *Notifier { return Default.WithFields(fields) }
+
+// Without returns a Notifier derived from the default one without keys
+// Drops inherited fields
+func Without(keys ...string) *Notifier { return Default.Without(keys...) }
@@ -0,0 +1,87 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"strings"
+)
+
+// FileChange is the state letter of a file, as in git status --short
+type FileChange byte
+
+const (
+	FileUnchanged FileChange = 0
+	FileModified  FileChange = 'M'
+	FileAdded     FileChange = 'A'
+	FileDeleted   FileChange = 'D'
+	FileRenamed   FileChange = 'R'
+	FileUntracked FileChange = '?'
+)
+
+// fileChangeColors colors the states when there is no staging column
+var fileChangeColors = map[FileChange]*color.Color{
+	FileModified:  color.New(color.FgYellow),
+	FileAdded:     color.New(color.FgGreen),
+	FileDeleted:   color.New(color.FgRed),
+	FileRenamed:   color.New(color.FgCyan),
+	FileUntracked: color.New(color.FgMagenta),
+}
+
+// Staged and unstaged colors of the staging column layout, as git uses them
+var (
+	stagedColor   = color.New(color.FgGreen)
+	unstagedColor = color.New(color.FgRed)
+)
+
+// FileState is a file listed by FileStatus
+type FileState struct {
+	Path   string
+	From   string     // Previous path of a renamed file
+	Change FileChange // Change in the working tree, or the only change
+	Staged FileChange // Change in the index; shows the staging column when set
+}
+
+// FileStatus lists files with their state letters like git status --short
+// Without staged changes each state has its own color; otherwise a staging
+// column is shown with index changes green and working tree changes red
+func (n *Notifier) FileStatus(entries []FileState) {
+	staging := false
+	for _, f := range entries {
+		staging = staging || f.Staged != FileUnchanged
+	}
+
+	var b strings.Builder
+	for _, f := range entries {
+		path := f.Path
+		if f.From != "" {
+			path = f.From + " → " + f.Path
+		}
+		switch {
+		case f.Change == FileUntracked:
+			marks := "?"
+			if staging {
+				marks = "??"
+			}
+			b.WriteString(paint(n.force(fileChangeColors[FileUntracked]), marks+" "+path))
+		case staging:
+			b.WriteString(paint(n.force(stagedColor), f.Staged.letter()))
+			b.WriteString(paint(n.force(unstagedColor), f.Change.letter()))
+			b.WriteString(" " + path)
+		default:
+			b.WriteString(paint(n.force(fileChangeColors[f.Change]), f.Change.letter()+" "+path))
+		}
+		b.WriteByte('\n')
+	}
+	n.writeBlock(b.String())
+}
+
+// letter returns the state letter, a space when unchanged
+func (c FileChange) letter() string {
+	if c == FileUnchanged {
+		return " "
+	}
+	return string(rune(c))
+}
+
+// FileStatus lists file states using the default Notifier
+// For VCS-adjacent tools and sync utilities
+func FileStatus(entries []FileState) { Default.FileStatus(entries) }
@@ -0,0 +1,119 @@
+package aurora
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// filterRule keeps or drops messages matching a pattern
+type filterRule struct {
+	pattern *regexp.Regexp
+	only    bool // Only rule when true, Suppress rule otherwise
+}
+
+// dropCounter counts messages removed by filters
+// Shared between derived Notifiers and guarded by their mutex
+type dropCounter struct {
+	count int
+}
+
+// Suppress drops every subsequent message matching pattern
+// Useful for muting chatty third-party output routed through aurora
+// Panics on an invalid pattern; dropped lines are reported by Close
+func (n *Notifier) Suppress(pattern string) {
+	n.addFilter(pattern, false)
+}
+
+// Only drops every subsequent message that matches none of the Only patterns
+// Several Only calls widen the allowed set; Suppress still applies on top
+// Panics on an invalid pattern; dropped lines are reported by Close
+func (n *Notifier) Only(pattern string) {
+	n.addFilter(pattern, true)
+}
+
+// Close reports how many messages filters suppressed and resets the count
+// Pending repeat counts from burst folding are written first, then final
+// counter and gauge values and the Latency budget report; OnExit hooks run
+// last. Call once the program is done logging, e.g. with defer
+func (n *Notifier) Close() error {
+	n.mu.Lock()
+	n.bursts.flushAll()
+
+	// The report itself must not be caught by the filters it summarizes
+	if dropped := n.dropped.count; dropped > 0 {
+		n.dropped.count = 0
+		msg := fmt.Sprintf(tr("%d %s suppressed"), dropped, plural(dropped, "line", "lines"))
+		n.write(n.newEntry(NoticeLevel, msg))
+	}
+	if limit := n.output.limit; limit != nil {
+		if notice := limit.notice(); notice != "" {
+			n.output.write([]byte(notice))
+		}
+	}
+	n.metricsReport()
+	n.latencyReport()
+	hooks := n.exitHooks
+	n.mu.Unlock()
+
+	if len(hooks) > 0 {
+		stats := n.Stats()
+		for _, hook := range hooks {
+			hook(n, stats)
+		}
+	}
+	// Only entries after the exit hooks count as logged after Close
+	n.mu.Lock()
+	n.output.closed = true
+	n.mu.Unlock()
+	n.Flush()
+	if c := n.output.clock.Swap(nil); c != nil {
+		c.close()
+	}
+	return nil
+}
+
+// addFilter compiles pattern and appends it to the filter chain
+func (n *Notifier) addFilter(pattern string, only bool) {
+	re := regexp.MustCompile(pattern)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	// Copy so Notifiers derived earlier keep their own filter set
+	n.filters = append(n.filters[:len(n.filters):len(n.filters)], filterRule{re, only})
+}
+
+// allowed reports whether msg passes the filter chain
+// Internal helper; callers must hold the mutex
+func (n *Notifier) allowed(msg string) bool {
+	hasOnly, matchedOnly := false, false
+	for _, f := range n.filters {
+		matched := f.pattern.MatchString(msg)
+		if !f.only && matched {
+			return false
+		}
+		if f.only {
+			hasOnly = true
+			matchedOnly = matchedOnly || matched
+		}
+	}
+	return !hasOnly || matchedOnly
+}
+
+// plural picks the singular or plural noun for count in the active locale
+func plural(count int, one, many string) string {
+	if count == 1 {
+		return tr(one)
+	}
+	return tr(many)
+}
+
+// Close reports suppressed messages of the default Notifier
+// Typically deferred in main
+func Close() error { return Default.Close() }
+
+// Only restricts the default Notifier to messages matching pattern
+// Narrows output to what matters
+func Only(pattern string) { Default.Only(pattern) }
+
+// Suppress drops messages matching pattern on the default Notifier
+// Mutes noisy output
+func Suppress(pattern string) { Default.Suppress(pattern) }
@@ -0,0 +1,194 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maskText replaces masked values in the Form summary
+const maskText = "••••••"
+
+// formField is a struct field Form prompts for
+type formField struct {
+	label    string
+	value    reflect.Value
+	def      string         // Answer used when the input is empty
+	pattern  *regexp.Regexp // Answers must match when set
+	required bool
+	masked   bool
+}
+
+// Form prompts for every exported field of the struct v points to
+// Tags: `form:"Label,required,masked"`, `default:"8080"` and `pattern:"^[a-z]+$"`;
+// `form:"-"` skips a field. Invalid answers are explained and asked again
+// Supports strings, bools, numbers and durations; prints a summary when done
+// Masked values are hidden in the summary only; answers are echoed while typed
+func (n *Notifier) Form(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("aurora: Form needs a pointer to a struct, got %T", v)
+	}
+	fields, err := formFields(rv.Elem())
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(fields))
+	for _, f := range fields {
+		if err := n.ask(f); err != nil {
+			return err
+		}
+		shown := fmt.Sprint(f.value.Interface())
+		if f.masked {
+			shown = maskText
+		}
+		rows = append(rows, []string{f.label, shown})
+	}
+
+	n.writeBlock(renderTable(nil, rows, terminalWidth(), func(_, col int, cell string) string {
+		if col == 0 {
+			return paint(n.force(color.New(color.Faint)), cell)
+		}
+		return cell
+	}))
+	return nil
+}
+
+// ask prompts for f until the answer parses and validates
+// Read errors end the prompt and are returned
+func (n *Notifier) ask(f formField) error {
+	question := f.label
+	if f.def != "" && !f.masked {
+		question += " [" + f.def + "]"
+	}
+	for {
+		answer, err := n.prompt(question + ": ")
+		if err != nil {
+			return err
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			answer = f.def
+		}
+
+		switch {
+		case answer == "" && f.required:
+			n.Warn(tr("%s is required"), f.label)
+		case answer != "" && f.pattern != nil && !f.pattern.MatchString(answer):
+			n.Warn(tr("%s must match %s"), f.label, f.pattern)
+		default:
+			if answer == "" {
+				return nil
+			}
+			if err := setField(f.value, answer); err != nil {
+				n.Warn("%s: %v", f.label, err)
+				continue
+			}
+			return nil
+		}
+	}
+}
+
+// formFields collects the prompted fields of the struct rv in declaration order
+// Unsupported field types and invalid patterns are reported before any prompt
+func formFields(rv reflect.Value) ([]formField, error) {
+	var fields []formField
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		label, opts, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if label == "-" {
+			continue
+		}
+		if label == "" {
+			label = field.Name
+		}
+		if !settable(field.Type) {
+			return nil, fmt.Errorf("aurora: Form field %s has unsupported type %s", field.Name, field.Type)
+		}
+
+		f := formField{label: label, value: rv.Field(i), def: field.Tag.Get("default")}
+		for _, opt := range strings.Split(opts, ",") {
+			f.required = f.required || opt == "required"
+			f.masked = f.masked || opt == "masked"
+		}
+		if pattern := field.Tag.Get("pattern"); pattern != "" {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("aurora: Form field %s: %w", field.Name, err)
+			}
+			f.pattern = re
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// durationType is set through time.ParseDuration rather than as an integer
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// settable reports whether setField can parse answers into type t
+func settable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setField parses s into the field fv according to its kind
+func setField(fv reflect.Value, s string) error {
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	}
+	return nil
+}
+
+// Form prompts for the fields of a struct using the default Notifier
+// Building block for setup wizards
+func Form(v any) error { return Default.Form(v) }
@@ -0,0 +1,85 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"slices"
+	"strings"
+)
+
+// cycleColor marks edges that lead back into the current path
+var cycleColor = color.New(color.FgRed, color.Bold)
+
+// Edge is a dependency from one graph node to another
+type Edge struct {
+	From, To string
+}
+
+// Graph prints a dependency graph as a tree with box-drawing connectors
+// Nodes without dependents start the trees; a node shown before is marked
+// "(*)" instead of being expanded again and edges closing a cycle are red
+func (n *Notifier) Graph(nodes []string, edges []Edge) {
+	nodes = slices.Clone(nodes)
+	children := make(map[string][]string)
+	incoming := make(map[string]bool)
+	for _, e := range edges {
+		children[e.From] = append(children[e.From], e.To)
+		incoming[e.To] = true
+		for _, name := range []string{e.From, e.To} {
+			if !slices.Contains(nodes, name) {
+				nodes = append(nodes, name)
+			}
+		}
+	}
+
+	g := &graphWriter{n: n, children: children, shown: make(map[string]bool)}
+	for _, name := range nodes {
+		if !incoming[name] {
+			g.node(name, "", "", nil)
+		}
+	}
+	// Nodes only reachable through a cycle have no root of their own
+	for _, name := range nodes {
+		if !g.shown[name] {
+			g.node(name, "", "", nil)
+		}
+	}
+	n.writeBlock(g.b.String())
+}
+
+// graphWriter renders the trees of a Graph call
+type graphWriter struct {
+	n        *Notifier
+	children map[string][]string
+	shown    map[string]bool
+	b        strings.Builder
+}
+
+// node writes name after lead and its children below it with indent
+// path holds the ancestors of name to detect cycles
+func (g *graphWriter) node(name, lead, indent string, path []string) {
+	faint := g.n.force(color.New(color.Faint))
+	switch {
+	case slices.Contains(path, name):
+		g.b.WriteString(paint(faint, lead) + paint(g.n.force(cycleColor), name+" ↺ "+tr("cycle")) + "\n")
+		return
+	case g.shown[name]:
+		g.b.WriteString(paint(faint, lead) + name + paint(faint, " (*)") + "\n")
+		return
+	}
+	g.shown[name] = true
+	g.b.WriteString(paint(faint, lead) + name + "\n")
+
+	path = append(path, name)
+	kids := g.children[name]
+	for i, child := range kids {
+		branch, next := "├── ", "│   "
+		if i == len(kids)-1 {
+			branch, next = "└── ", "    "
+		}
+		g.node(child, indent+branch, indent+next, path)
+	}
+}
+
+// Graph prints a dependency graph using the default Notifier
+// Shows resolution results of package managers and build tools
+func Graph(nodes []string, edges []Edge) { Default.Graph(nodes, edges) }
@@ -0,0 +1,60 @@
+package aurora
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Heartbeat reports status every interval until ctx is done
+// Keeps CI systems from killing long jobs that are otherwise silent
+// Terminals get a line in the live region; other writers get one
+// Info entry per tick. A nil status reports the elapsed time only
+func (n *Notifier) Heartbeat(ctx context.Context, interval time.Duration, status func() string) {
+	if interval <= 0 {
+		return
+	}
+	go n.beat(ctx, interval, status)
+}
+
+// beat is the worker started by Heartbeat
+func (n *Notifier) beat(ctx context.Context, interval time.Duration, status func() string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	var handle liveLine
+	for {
+		select {
+		case <-ctx.Done():
+			n.mu.Lock()
+			n.output.live.remove(&handle)
+			n.mu.Unlock()
+			return
+		case <-ticker.C:
+		}
+
+		elapsed := time.Since(start).Round(time.Second)
+		msg := tr("still running")
+		if status != nil {
+			msg = status()
+		}
+		msg = fmt.Sprintf(tr("%s (%s elapsed)"), msg, elapsed)
+
+		n.mu.Lock()
+		live := n.output.terminal()
+		if live {
+			n.output.live.set(&handle, n.colorMode.apply(paint(n.color(InfoLevel), n.symbol(InfoLevel)+" "+n.formatWithPrefix(msg))))
+		}
+		n.mu.Unlock()
+		if !live {
+			n.Inlinef(InfoLevel, "%s", msg)
+		}
+	}
+}
+
+// Heartbeat reports status periodically using the default Notifier
+// Stops when ctx is cancelled
+func Heartbeat(ctx context.Context, interval time.Duration, status func() string) {
+	Default.Heartbeat(ctx, interval, status)
+}
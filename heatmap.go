@@ -0,0 +1,97 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"strings"
+	"time"
+)
+
+// heatColors shade heatmap cells from no activity to the busiest days
+// 256-color backgrounds give the green ramp the basic palette lacks
+var heatColors = []*color.Color{
+	color.New(48, 5, 236),
+	color.New(48, 5, 22),
+	color.New(48, 5, 28),
+	color.New(48, 5, 34),
+	color.New(48, 5, 40),
+}
+
+// heatGlyphs stand in for heatColors when color is off
+var heatGlyphs = []string{"·", "░", "▒", "▓", "█"}
+
+// Heatmap prints daily counts as a calendar of weeks, GitHub style
+// Columns are weeks starting on Sunday and rows weekdays; cells darken
+// with activity and fall back to shade characters without color
+func (n *Notifier) Heatmap(dates map[time.Time]int) {
+	if len(dates) == 0 {
+		return
+	}
+	day := func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC) }
+	counts := make(map[time.Time]int)
+	var first, last time.Time
+	for t, count := range dates {
+		d := day(t)
+		counts[d] += count
+		if first.IsZero() || d.Before(first) {
+			first = d
+		}
+		if d.After(last) {
+			last = d
+		}
+	}
+	peak := 0
+	for _, count := range counts {
+		peak = max(peak, count)
+	}
+	first = first.AddDate(0, 0, -int(first.Weekday()))
+	weeks := int(last.Sub(first).Hours()/24)/7 + 1
+
+	cell := func(level int) string {
+		if !n.colored() {
+			return heatGlyphs[level] + " "
+		}
+		return paint(n.force(heatColors[level]), " ") + " "
+	}
+	const label = 4 // Width of the weekday labels
+
+	var months strings.Builder
+	months.WriteString(strings.Repeat(" ", label))
+	for w := 0; w < weeks; w++ {
+		sunday := first.AddDate(0, 0, 7*w)
+		if used := displayWidth(months.String()); (w == 0 || sunday.Day() <= 7) && used <= label+2*w {
+			months.WriteString(strings.Repeat(" ", label+2*w-used))
+			months.WriteString(localTime(sunday, "Jan"))
+		}
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(months.String(), " ") + "\n")
+	for weekday := 0; weekday < 7; weekday++ {
+		name := ""
+		if weekday%2 == 1 {
+			name = localTime(first.AddDate(0, 0, weekday), "Mon")
+		}
+		b.WriteString(name + strings.Repeat(" ", label-displayWidth(name)))
+		for w := 0; w < weeks; w++ {
+			d := first.AddDate(0, 0, 7*w+weekday)
+			if d.After(last) {
+				break
+			}
+			level := 0
+			if count := counts[d]; count > 0 && peak > 0 {
+				level = (count*4 + peak - 1) / peak
+			}
+			b.WriteString(cell(level))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat(" ", label) + tr("Less") + " ")
+	for level := range heatColors {
+		b.WriteString(cell(level))
+	}
+	b.WriteString(tr("More") + "\n")
+	n.writeBlock(b.String())
+}
+
+// Heatmap prints a calendar heatmap using the default Notifier
+// Activity and usage reports at a glance
+func Heatmap(dates map[time.Time]int) { Default.Heatmap(dates) }
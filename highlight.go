@@ -0,0 +1,71 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// highlightRule styles every match of a pattern inside messages
+type highlightRule struct {
+	pattern *regexp.Regexp
+	style   Style
+}
+
+// HighlightRule styles matches of pattern in every subsequent message
+// Applies regardless of level, e.g. to make IDs or "FAILED" stand out
+// Earlier rules win where matches overlap; panics on an invalid pattern
+func (n *Notifier) HighlightRule(pattern string, style Style) {
+	re := regexp.MustCompile(pattern)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	// Copy so Notifiers derived earlier keep their own rule set
+	n.highlights = append(n.highlights[:len(n.highlights):len(n.highlights)], highlightRule{re, style})
+}
+
+// highlight renders msg in color c with highlight rule matches styled
+// Internal helper; callers must hold the mutex
+func (n *Notifier) highlight(c *color.Color, msg string) string {
+	type span struct {
+		start, end int
+		style      Style
+	}
+	var spans []span
+	for _, rule := range n.highlights {
+		for _, loc := range rule.pattern.FindAllStringIndex(msg, -1) {
+			if loc[0] < loc[1] {
+				spans = append(spans, span{loc[0], loc[1], rule.style})
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return paint(c, msg)
+	}
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var b strings.Builder
+	pos := 0
+	for _, s := range spans {
+		if s.start < pos {
+			continue // Overlaps an earlier match
+		}
+		b.WriteString(paint(c, msg[pos:s.start]))
+		b.WriteString(s.style.Sprint(msg[s.start:s.end]))
+		pos = s.end
+	}
+	b.WriteString(paint(c, msg[pos:]))
+	return b.String()
+}
+
+// paint colors s with c, leaving it untouched when c is nil or s is empty
+func paint(c *color.Color, s string) string {
+	if c == nil || s == "" {
+		return s
+	}
+	return c.Sprint(s)
+}
+
+// HighlightRule styles matches of pattern on the default Notifier
+// Makes recurring tokens stand out in all output
+func HighlightRule(pattern string, style Style) { Default.HighlightRule(pattern, style) }
@@ -0,0 +1,47 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// methodColors holds the conventional color of each HTTP method
+// Read-only methods are cool, writes warm and deletes red
+var methodColors = map[string]color.Attribute{
+	http.MethodGet:     color.FgBlue,
+	http.MethodHead:    color.FgBlue,
+	http.MethodOptions: color.FgBlue,
+	http.MethodPost:    color.FgGreen,
+	http.MethodPut:     color.FgYellow,
+	http.MethodPatch:   color.FgYellow,
+	http.MethodDelete:  color.FgRed,
+}
+
+// Status renders an HTTP status code colored by class
+// 2xx green, 3xx cyan, 4xx yellow, 5xx red; other codes are left plain
+func Status(code int) Value {
+	v := Value{value: strconv.Itoa(code)}
+	switch code / 100 {
+	case 2:
+		v.attrs = []color.Attribute{color.FgGreen}
+	case 3:
+		v.attrs = []color.Attribute{color.FgCyan}
+	case 4:
+		v.attrs = []color.Attribute{color.FgYellow}
+	case 5:
+		v.attrs = []color.Attribute{color.FgRed}
+	}
+	return v
+}
+
+// Method renders an HTTP method in its conventional color
+// e.g. GET blue, POST green, DELETE red; unknown methods are left plain
+func Method(method string) Value {
+	v := Value{value: method}
+	if attr, ok := methodColors[strings.ToUpper(method)]; ok {
+		v.attrs = []color.Attribute{attr}
+	}
+	return v
+}
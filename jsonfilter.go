@@ -0,0 +1,168 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// omitted marks array slots dropped by a filter until they are compacted away
+type omitted struct{}
+
+// JSONFilter logs the parts of v selected by paths as colorized JSON
+// Paths are dot separated keys or array indexes, "*" matches any key or element
+// Paths starting with "-" are removed instead of kept, e.g. "-user.password"
+// Without inclusive paths the whole value is kept before exclusions apply
+func (n *Notifier) JSONFilter(title string, v any, paths ...string) {
+	filtered, err := filterJSON(v, paths)
+	if err != nil {
+		n.marshalFailed(fmt.Errorf("failed to filter JSON: %w", err))
+		return
+	}
+	n.JSONIndent(title, IndentSpace2, filtered)
+}
+
+// filterJSON projects and prunes the JSON form of v
+// Numbers are kept as json.Number so they print exactly as encoded
+func filterJSON(v any, paths []string) (any, error) {
+	ev, err := encodable(v)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var includes, excludes [][]string
+	for _, p := range paths {
+		if rest, ok := strings.CutPrefix(p, "-"); ok {
+			excludes = append(excludes, strings.Split(rest, "."))
+		} else {
+			includes = append(includes, strings.Split(p, "."))
+		}
+	}
+
+	if len(includes) > 0 {
+		var projected any
+		for _, path := range includes {
+			projected = includePath(projected, doc, path)
+		}
+		doc = projected
+	}
+	for _, path := range excludes {
+		excludePath(doc, path)
+	}
+	return compactJSON(doc), nil
+}
+
+// includePath copies the branch of src addressed by path into dst
+// Returns the updated dst; unmatched paths leave dst untouched
+func includePath(dst, src any, path []string) any {
+	if len(path) == 0 {
+		return src
+	}
+	switch s := src.(type) {
+	case map[string]any:
+		d, _ := dst.(map[string]any)
+		if d == nil {
+			d = make(map[string]any)
+		}
+		for key, value := range s {
+			if path[0] == "*" || path[0] == key {
+				d[key] = includePath(d[key], value, path[1:])
+			}
+		}
+		return d
+	case []any:
+		d, _ := dst.([]any)
+		if d == nil {
+			d = make([]any, len(s))
+			for i := range d {
+				d[i] = omitted{}
+			}
+		}
+		for i, value := range s {
+			if matchIndex(path[0], i) {
+				prev := d[i]
+				if _, ok := prev.(omitted); ok {
+					prev = nil
+				}
+				d[i] = includePath(prev, value, path[1:])
+			}
+		}
+		return d
+	}
+	return dst
+}
+
+// excludePath removes the branch addressed by path from node in place
+func excludePath(node any, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	switch t := node.(type) {
+	case map[string]any:
+		for key, value := range t {
+			if path[0] != "*" && path[0] != key {
+				continue
+			}
+			if len(path) == 1 {
+				delete(t, key)
+			} else {
+				excludePath(value, path[1:])
+			}
+		}
+	case []any:
+		for i, value := range t {
+			if !matchIndex(path[0], i) {
+				continue
+			}
+			if len(path) == 1 {
+				t[i] = omitted{}
+			} else {
+				excludePath(value, path[1:])
+			}
+		}
+	}
+}
+
+// matchIndex reports whether a path segment selects array index i
+func matchIndex(segment string, i int) bool {
+	if segment == "*" {
+		return true
+	}
+	idx, err := strconv.Atoi(segment)
+	return err == nil && idx == i
+}
+
+// compactJSON drops omitted array slots left behind by filtering
+func compactJSON(node any) any {
+	switch t := node.(type) {
+	case map[string]any:
+		for key, value := range t {
+			t[key] = compactJSON(value)
+		}
+	case []any:
+		kept := t[:0]
+		for _, value := range t {
+			if _, ok := value.(omitted); !ok {
+				kept = append(kept, compactJSON(value))
+			}
+		}
+		return kept
+	}
+	return node
+}
+
+// JSONFilter logs selected JSON paths using the default Notifier
+// Keeps noisy or sensitive fields out of the output
+func JSONFilter(title string, v any, paths ...string) { Default.JSONFilter(title, v, paths...) }
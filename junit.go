@@ -0,0 +1,120 @@
+package aurora
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+// stepResult is the outcome of a spinner, Mux task or Record call
+type stepResult struct {
+	name    string
+	start   time.Time
+	took    time.Duration
+	err     error // Failure, nil when the step passed
+	skipped bool  // Whether the step was stopped without a result
+}
+
+// resultLog collects step results for WriteJUnit
+// Shared between derived Notifiers and guarded by their mutex
+type resultLog struct {
+	results []stepResult
+}
+
+// Record adds the outcome of a step to the results written by WriteJUnit
+// Spinners and Mux tasks record themselves; a nil err means it passed
+func (n *Notifier) Record(name string, took time.Duration, err error) {
+	n.record(stepResult{name: name, start: time.Now().Add(-took), took: took, err: err})
+}
+
+// record appends r to the shared result log
+func (n *Notifier) record(r stepResult) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.results.results = append(n.results.results, r)
+	n.output.event("result", resultEvent(r))
+}
+
+// JUnit XML documents as read by CI systems
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Skipped  int          `xml:"skipped,attr"`
+		Time     string       `xml:"time,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Skipped  int         `xml:"skipped,attr"`
+		Time     string      `xml:"time,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		Skipped   *struct{}     `xml:"skipped,omitempty"`
+	}
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// seconds formats d as the decimal seconds JUnit expects
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+// WriteJUnit writes the recorded step results as a JUnit XML suite
+// Lets CI systems show pass/fail and durations of the steps the console
+// showed; spinners stopped without a result count as skipped
+func (n *Notifier) WriteJUnit(w io.Writer, suite string) error {
+	n.mu.Lock()
+	results := append([]stepResult(nil), n.results.results...)
+	n.mu.Unlock()
+
+	s := junitSuite{Name: suite, Tests: len(results), Cases: []junitCase{}}
+	var total time.Duration
+	for _, r := range results {
+		c := junitCase{Name: r.name, ClassName: suite, Time: seconds(r.took)}
+		switch {
+		case r.skipped:
+			s.Skipped++
+			c.Skipped = &struct{}{}
+		case r.err != nil:
+			s.Failures++
+			c.Failure = &junitFailure{Message: r.err.Error(), Text: r.err.Error()}
+		}
+		total += r.took
+		s.Cases = append(s.Cases, c)
+	}
+	s.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", IndentSpace2)
+	err := enc.Encode(junitSuites{
+		Tests: s.Tests, Failures: s.Failures, Skipped: s.Skipped, Time: s.Time,
+		Suites: []junitSuite{s},
+	})
+	_, nl := io.WriteString(w, "\n")
+	return errors.Join(err, nl)
+}
+
+// Record adds a step result to the default Notifier
+// Included in WriteJUnit reports
+func Record(name string, took time.Duration, err error) { Default.Record(name, took, err) }
+
+// WriteJUnit writes the step results of the default Notifier as JUnit XML
+// For CI systems next to the console output
+func WriteJUnit(w io.Writer, suite string) error { return Default.WriteJUnit(w, suite) }
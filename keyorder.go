@@ -0,0 +1,200 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// KeyOrder arranges object keys in JSON dumps, previews and entry fields
+// The zero value keeps the natural order: struct fields as declared,
+// map keys sorted and entry fields as attached
+type KeyOrder struct {
+	Sort      bool     // Sort keys alphabetically, struct fields included
+	First     []string // Keys placed before all others in this order, e.g. "id", "name", "error"
+	OmitEmpty bool     // Hide keys whose value is null, "", [] or {}
+}
+
+// keyOrder is the order set with SetKeyOrder, guarded by mu
+var keyOrder KeyOrder
+
+// SetKeyOrder arranges object keys in all JSON, Preview and field output
+// Sorted keys with the important ones first make repeated dumps diffable
+// and scannable; KeyOrder{} restores the natural order
+func SetKeyOrder(order KeyOrder) {
+	mu.Lock()
+	defer mu.Unlock()
+	order.First = slices.Clone(order.First)
+	keyOrder = order
+}
+
+// currentKeyOrder returns the order set with SetKeyOrder
+func currentKeyOrder() KeyOrder {
+	mu.RLock()
+	defer mu.RUnlock()
+	return keyOrder
+}
+
+// natural reports whether the order leaves keys as they are
+func (o KeyOrder) natural() bool {
+	return !o.Sort && len(o.First) == 0 && !o.OmitEmpty
+}
+
+// compare orders keys: listed First keys by position, then the rest
+// alphabetically when Sort is set; other keys compare equal
+func (o KeyOrder) compare(a, b string) int {
+	ra, rb := o.rank(a), o.rank(b)
+	switch {
+	case ra != rb:
+		return ra - rb
+	case o.Sort:
+		return strings.Compare(a, b)
+	}
+	return 0
+}
+
+// rank returns the position of key in First, len(First) when not listed
+func (o KeyOrder) rank(key string) int {
+	if i := slices.Index(o.First, key); i >= 0 {
+		return i
+	}
+	return len(o.First)
+}
+
+// member is a key and value of an orderedObject
+type member struct {
+	key   string
+	value any
+}
+
+// orderedObject is a JSON object that keeps its members in order
+type orderedObject []member
+
+// MarshalJSON encodes the members in their order
+func (obj orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range obj {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.key)
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// arrangeKeys applies the KeyOrder set with SetKeyOrder to the JSON form of v
+// Returns v untouched when keys keep their natural order
+func arrangeKeys(v any) (any, error) {
+	order := currentKeyOrder()
+	if order.natural() {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return order.decode(dec)
+}
+
+// decode reads the next JSON value from dec with objects arranged
+func (o KeyOrder) decode(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+	if delim == '[' {
+		items := []any{}
+		for dec.More() {
+			item, err := o.decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err = dec.Token()
+		return items, err
+	}
+
+	obj := orderedObject{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		value, err := o.decode(dec)
+		if err != nil {
+			return nil, err
+		}
+		if o.OmitEmpty && emptyJSON(value) {
+			continue
+		}
+		obj = append(obj, member{key: key.(string), value: value})
+	}
+	slices.SortStableFunc(obj, func(a, b member) int { return o.compare(a.key, b.key) })
+	_, err = dec.Token()
+	return obj, err
+}
+
+// emptyJSON reports whether a decoded value is null, "", [] or {}
+func emptyJSON(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case []any:
+		return len(t) == 0
+	case orderedObject:
+		return len(t) == 0
+	}
+	return false
+}
+
+// emptyValue reports whether v encodes to null, "", [] or {}
+// The test emptyJSON applies to dumps, so nil slices, empty maps and typed
+// nil pointers are hidden from fields too; values that fail to encode are kept
+func emptyValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	switch string(data) {
+	case "null", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
+
+// arrangeFields applies the KeyOrder set with SetKeyOrder to entry fields
+// Returns fields itself when they keep their natural order
+func arrangeFields(fields []Field) []Field {
+	order := currentKeyOrder()
+	if order.natural() || len(fields) == 0 {
+		return fields
+	}
+	arranged := slices.Clone(fields)
+	if order.OmitEmpty {
+		arranged = slices.DeleteFunc(arranged, func(f Field) bool { return emptyValue(f.Value) })
+	}
+	slices.SortStableFunc(arranged, func(a, b Field) int { return order.compare(a.Key, b.Key) })
+	return arranged
+}
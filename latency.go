@@ -0,0 +1,91 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"time"
+)
+
+// Colors of the over and under budget indicators
+var (
+	overBudgetColor  = color.New(color.FgRed, color.Bold)
+	underBudgetColor = color.New(color.FgGreen)
+)
+
+// latencyStat aggregates the Latency calls of a single name
+type latencyStat struct {
+	budget time.Duration // Budget of the latest call
+	max    time.Duration // Slowest call
+	calls  int
+	over   int // Calls that exceeded their budget
+}
+
+// latencyLog collects Latency calls for the report written by Close
+// Shared between derived Notifiers and guarded by their mutex
+type latencyLog struct {
+	names []string // Names in order of first appearance
+	stats map[string]*latencyStat
+}
+
+// newLatencyLog creates an empty latencyLog
+func newLatencyLog() *latencyLog {
+	return &latencyLog{stats: make(map[string]*latencyStat)}
+}
+
+// Latency reports how long name took against its budget
+// e.g. "[▲ 120%] db.query 120ms (budget 100ms)"; over budget entries are warnings
+// Close follows up with a per-name report of calls within budget
+func (n *Notifier) Latency(name string, took, budget time.Duration) {
+	pct := 0
+	if budget > 0 {
+		pct = int(took * 100 / budget)
+	}
+	level, indicator, c := InfoLevel, "▼", underBudgetColor
+	if took > budget {
+		level, indicator, c = WarnLevel, "▲", overBudgetColor
+	}
+
+	var e entry
+	if n.enabled(level) {
+		e = n.newEntry(level, fmt.Sprintf(tr("%s %s (budget %s)"), name, took, budget))
+		e.Tag, e.tagColor = fmt.Sprintf("[%s %d%%]", indicator, pct), c
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	stat, ok := n.latency.stats[name]
+	if !ok {
+		stat = &latencyStat{}
+		n.latency.stats[name] = stat
+		n.latency.names = append(n.latency.names, name)
+	}
+	stat.budget, stat.max = budget, max(stat.max, took)
+	stat.calls++
+	if took > budget {
+		stat.over++
+	}
+
+	if e.Message != "" {
+		n.emit(e)
+	}
+}
+
+// latencyReport writes one line per name seen by Latency and clears the log
+// Internal helper for Close; callers must hold the mutex
+func (n *Notifier) latencyReport() {
+	for _, name := range n.latency.names {
+		stat := n.latency.stats[name]
+		level := NoticeLevel
+		if stat.over > 0 {
+			level = WarnLevel
+		}
+		msg := fmt.Sprintf(tr("latency %s: %d/%d within %s budget, max %s"),
+			name, stat.calls-stat.over, stat.calls, stat.budget, stat.max)
+		n.write(n.newEntry(level, msg))
+	}
+	*n.latency = *newLatencyLog()
+}
+
+// Latency reports a duration against its budget using the default Notifier
+// Standardizes latency checks in SRE tooling
+func Latency(name string, took, budget time.Duration) { Default.Latency(name, took, budget) }
@@ -0,0 +1,23 @@
+package aurora
+
+import "fmt"
+
+// Lazy defers building a log argument until the message is formatted
+// Entries below the Notifier level are never formatted, so fn never runs
+// e.g. aurora.Debug("state: %v", aurora.Lazy(func() any { return dump(s) }))
+type Lazy func() any
+
+// Format evaluates the function and formats its result with the original verb
+func (l Lazy) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, verb), l())
+}
+
+// Enabled reports whether entries at level would be written
+// Cheap enough to guard expensive logging in hot paths
+func (n *Notifier) Enabled(level LogLevel) bool {
+	return n.enabled(level)
+}
+
+// Enabled reports whether the default Notifier writes entries at level
+// Guards costly debug output
+func Enabled(level LogLevel) bool { return Default.Enabled(level) }
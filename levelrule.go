@@ -0,0 +1,114 @@
+package aurora
+
+import (
+	"io"
+	"maps"
+	"regexp"
+)
+
+// LevelRule maps lines matching Pattern to a log level
+// Used to recolor plain text output from other programs
+type LevelRule struct {
+	Pattern *regexp.Regexp // Expression tested against each line
+	Level   LogLevel       // Level assigned to matching lines
+}
+
+// Rule compiles pattern into a LevelRule
+// Panics on an invalid pattern, like regexp.MustCompile
+func Rule(pattern string, level LogLevel) LevelRule {
+	return LevelRule{Pattern: regexp.MustCompile(pattern), Level: level}
+}
+
+// DetectLevel returns the level of the first rule matching line
+// Reports false when no rule matches
+func DetectLevel(line string, rules []LevelRule) (LogLevel, bool) {
+	for _, rule := range rules {
+		if rule.Pattern != nil && rule.Pattern.MatchString(line) {
+			return rule.Level, true
+		}
+	}
+	return NoLevel, false
+}
+
+// emitDetected writes line at the level chosen by rules
+// Lines matching no rule are printed plain
+func (n *Notifier) emitDetected(line string, rules []LevelRule) {
+	if level, ok := DetectLevel(line, rules); ok {
+		n.Inlinef(level, "%s", line)
+		return
+	}
+	n.Printf(NoLevel, "%s", line)
+}
+
+// DefaultLevelRules recognize the level keywords common in plain log output
+// Covers stdlib style tags like "ERROR", logfmt "level=warn" and Go panics
+// Ordered from most to least severe so the strongest keyword wins
+var DefaultLevelRules = []LevelRule{
+	Rule(`^panic:|^fatal error:|\b(?:FATAL|CRITICAL|CRIT)\b|(?i:level=(?:fatal|crit(?:ical)?)\b)`, CriticalLevel),
+	Rule(`\b(?:ERROR|ERR)\b|^(?i:error:)|(?i:level=err(?:or)?\b)`, ErrorLevel),
+	Rule(`\b(?:WARN|WARNING)\b|^(?i:warning:)|(?i:level=warn(?:ing)?\b)`, WarnLevel),
+	Rule(`\bNOTICE\b|(?i:level=notice\b)`, NoticeLevel),
+	Rule(`\bINFO\b|(?i:level=info\b)`, InfoLevel),
+	Rule(`\bDEBUG\b|(?i:level=debug\b)`, DebugLevel),
+	Rule(`\bTRACE\b|(?i:level=trace\b)`, TraceLevel),
+}
+
+// AutoLevelWriter returns a writer that levels each line by its content
+// Uses DefaultLevelRules unless rules are given; unmatched lines print plain
+// Close flushes a final line that lacks its newline
+func (n *Notifier) AutoLevelWriter(rules ...LevelRule) io.WriteCloser {
+	if len(rules) == 0 {
+		rules = DefaultLevelRules
+	}
+	return newLineWriter(func(line string) { n.emitDetected(line, rules) })
+}
+
+// AutoLevelWriter returns a level detecting writer bound to the default Notifier
+// Lets existing log output adopt aurora colors and symbols
+func AutoLevelWriter(rules ...LevelRule) io.WriteCloser {
+	return Default.AutoLevelWriter(rules...)
+}
+
+// Writer returns a writer that prints each written line at level
+// Handy as the output of a *log.Logger or a subprocess
+// Close flushes a final line that lacks its newline
+func (n *Notifier) Writer(level LogLevel) io.WriteCloser {
+	return newLineWriter(func(line string) {
+		if level == NoLevel {
+			n.Printf(NoLevel, "%s", line)
+			return
+		}
+		n.Inlinef(level, "%s", line)
+	})
+}
+
+// Writer returns a leveled line writer bound to the default Notifier
+// e.g. log.SetOutput(aurora.Writer(aurora.InfoLevel))
+func Writer(level LogLevel) io.WriteCloser { return Default.Writer(level) }
+
+// RemapLevel returns a Notifier writing entries logged at from as to
+// Demotes a noisy library's errors to warnings, or its chatter to debug,
+// without losing them; the remapped level decides filtering as well
+func (n *Notifier) RemapLevel(from, to LogLevel) *Notifier {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	child := n.derive()
+	child.remap = maps.Clone(n.remap)
+	if child.remap == nil {
+		child.remap = make(map[LogLevel]LogLevel)
+	}
+	child.remap[from] = to
+	return child
+}
+
+// mapped returns the level entries logged at level are written as
+func (n *Notifier) mapped(level LogLevel) LogLevel {
+	if to, ok := n.remap[level]; ok {
+		return to
+	}
+	return level
+}
+
+// RemapLevel returns a Notifier derived from the default one with from written as to
+// Useful as the writer injected into third-party code
+func RemapLevel(from, to LogLevel) *Notifier { return Default.RemapLevel(from, to) }
@@ -0,0 +1,129 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/fatih/color"
+	"unicode/utf8"
+)
+
+// Dump guards applied to JSON, XML and TOML output
+// Zero disables a limit; both are guarded by the package mutex
+var (
+	maxDumpBytes int // Largest rendered document written in full
+	maxDumpDepth int // Deepest nesting level rendered for JSON values
+)
+
+// SetMaxDumpBytes caps the size of a single rendered document
+// Longer output is cut at a line boundary and followed by a truncation notice
+// Use 0 to print documents of any size
+func SetMaxDumpBytes(n int) {
+	mu.Lock()
+	defer mu.Unlock()
+	maxDumpBytes = n
+}
+
+// SetMaxDepth caps how deeply nested JSON values are rendered
+// Deeper objects and arrays are replaced by a short summary
+// Use 0 to render every level
+func SetMaxDepth(depth int) {
+	mu.Lock()
+	defer mu.Unlock()
+	maxDumpDepth = depth
+}
+
+// limitDump enforces the byte limit on a rendered document
+// Only visible text counts toward the limit; the notice reports the full
+// size so readers know what was hidden
+func limitDump(data []byte) []byte {
+	mu.RLock()
+	limit := maxDumpBytes
+	mu.RUnlock()
+	if limit <= 0 || len(data) <= limit {
+		return data
+	}
+	total := len(StripANSI(string(data)))
+	if total <= limit {
+		return data
+	}
+
+	cut := data[:visibleOffset(data, limit)]
+	if i := bytes.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i]
+	}
+	// Never split a multi-byte character when no newline was found
+	for len(cut) > 0 {
+		if r, size := utf8.DecodeLastRune(cut); r != utf8.RuneError || size > 1 {
+			break
+		}
+		cut = cut[:len(cut)-1]
+	}
+
+	var b bytes.Buffer
+	b.Write(cut)
+	if !color.NoColor {
+		b.WriteString("\x1b[0m")
+	}
+	b.WriteByte('\n')
+	b.WriteString(color.New(color.Faint).Sprintf(tr("… truncated (%s total)"), humanBytes(total)))
+	return b.Bytes()
+}
+
+// visibleOffset returns the index in data just past limit bytes of visible text
+// Escape sequences are skipped whole, so cutting there never splits one
+func visibleOffset(data []byte, limit int) int {
+	visible, i := 0, 0
+	for _, seq := range ansiPattern.FindAllIndex(data, -1) {
+		if visible+seq[0]-i >= limit {
+			break
+		}
+		visible += seq[0] - i
+		i = seq[1]
+	}
+	return min(len(data), i+limit-visible)
+}
+
+// limitDepth replaces JSON content nested deeper than the configured depth
+// Returns v unchanged when no depth limit is set
+func limitDepth(v any) (any, error) {
+	mu.RLock()
+	depth := maxDumpDepth
+	mu.RUnlock()
+	if depth <= 0 {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return pruneDepth(doc, depth), nil
+}
+
+// pruneDepth summarizes containers found below the remaining depth
+func pruneDepth(node any, depth int) any {
+	switch t := node.(type) {
+	case map[string]any:
+		if depth == 0 {
+			return fmt.Sprintf("{… %d keys}", len(t))
+		}
+		for key, value := range t {
+			t[key] = pruneDepth(value, depth-1)
+		}
+	case []any:
+		if depth == 0 {
+			return fmt.Sprintf("[… %d items]", len(t))
+		}
+		for i, value := range t {
+			t[i] = pruneDepth(value, depth-1)
+		}
+	}
+	return node
+}
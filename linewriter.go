@@ -0,0 +1,52 @@
+package aurora
+
+import (
+	"bytes"
+	"sync"
+)
+
+// lineWriter is an io.WriteCloser that hands each complete line to fn
+// Partial lines are buffered until their newline arrives or Close is called
+// Shared by the writers that re-emit foreign output through a Notifier
+type lineWriter struct {
+	mu  sync.Mutex        // Serializes writes and line delivery
+	buf bytes.Buffer      // Bytes after the last complete line
+	fn  func(line string) // Receives lines without the trailing newline
+}
+
+// newLineWriter creates a lineWriter delivering lines to fn
+func newLineWriter(fn func(line string)) *lineWriter {
+	return &lineWriter{fn: fn}
+}
+
+// Write buffers p and delivers every complete line it contains
+// Carriage returns before the newline are dropped
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := string(bytes.TrimSuffix(w.buf.Next(i + 1)[:i], []byte{'\r'}))
+		w.fn(line)
+	}
+	return len(p), nil
+}
+
+// Close delivers any unterminated final line
+// The writer can keep being used afterwards
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() > 0 {
+		line := w.buf.String()
+		w.buf.Reset()
+		w.fn(line)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package aurora
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Catalog maps built-in English message formats to translations
+// Keys are the format strings as written in aurora, e.g. "%d %s suppressed"
+type Catalog map[string]string
+
+// Locale holds the translations for one language
+// Empty fields fall back to the English defaults
+type Locale struct {
+	Levels     map[LogLevel]string // Level names shown in plain output, e.g. "WARNUNG"
+	Months     [12]string          // Full month names, January first
+	Days       [7]string           // Full weekday names, Sunday first
+	TimeFormat string              // Timestamp layout replacing DefaultTimeFormat
+	Messages   Catalog             // Translations of built-in messages
+}
+
+// German is the built-in "de" locale
+var German = Locale{
+	Levels: map[LogLevel]string{
+		TraceLevel:    "TRACE",
+		DebugLevel:    "DEBUG",
+		InfoLevel:     "INFO",
+		NoticeLevel:   "HINWEIS",
+		WarnLevel:     "WARNUNG",
+		ErrorLevel:    "FEHLER",
+		AlertLevel:    "ALARM",
+		CriticalLevel: "KRITISCH",
+	},
+	Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember"},
+	Days:       [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	TimeFormat: "02.01.2006 15:04:05",
+	Messages: Catalog{
+		"%d %s suppressed":                      "%d %s unterdrückt",
+		"line":                                  "Zeile",
+		"lines":                                 "Zeilen",
+		"row":                                   "Zeile",
+		"rows":                                  "Zeilen",
+		"warning":                               "Warnung",
+		"warnings":                              "Warnungen",
+		"error":                                 "Fehler",
+		"errors":                                "Fehler",
+		"verbose output before error:":          "ausführliche Ausgabe vor dem Fehler:",
+		"succeeded on attempt %d/%d":            "erfolgreich bei Versuch %d/%d",
+		"attempt %d/%d failed: %v, retrying in": "Versuch %d/%d fehlgeschlagen: %v, neuer Versuch in",
+		"failed after %d attempts: %v":          "fehlgeschlagen nach %d Versuchen: %v",
+		"%s: %s left before deadline":           "%s: noch %s bis zur Frist",
+		"%s: deadline exceeded":                 "%s: Frist überschritten",
+		"failed to parse CSV: %v":               "CSV konnte nicht gelesen werden: %v",
+		"CSV: no records":                       "CSV: keine Datensätze",
+		"still running":                         "läuft noch",
+		"%s (%s elapsed)":                       "%s (%s vergangen)",
+		"%s (repeated %d more %s)":              "%s (%d weitere %s wiederholt)",
+		"time":                                  "Mal",
+		"times":                                 "Mal",
+		"%q logged %d times within %s":          "%q wurde %d Mal innerhalb von %s gemeldet",
+		"finished in %s: %s":                    "fertig nach %s: %s",
+		"%s (confirmation skipped)":             "%s (Bestätigung übersprungen)",
+		"Type %q to continue":                   "Zum Fortfahren %q eingeben",
+		"confirmation did not match, aborted":   "Bestätigung stimmt nicht überein, abgebrochen",
+		"Choose 1-%d: ":                         "Auswahl 1-%d: ",
+		"invalid choice %q":                     "ungültige Auswahl %q",
+		"%s is required":                        "%s ist erforderlich",
+		"%s must match %s":                      "%s muss %s entsprechen",
+		"(copied to clipboard)":                 "(in die Zwischenablage kopiert)",
+		"%s %s (budget %s)":                     "%s %s (Budget %s)",
+		"latency %s: %d/%d within %s budget, max %s": "Latenz %s: %d/%d innerhalb von %s Budget, max %s",
+		"… %d %s dropped by the output rate limit":   "… %d %s durch das Ausgabelimit verworfen",
+		"note":                     "Hinweis",
+		"help":                     "Hilfe",
+		"no problems found":        "keine Probleme gefunden",
+		"no differences":           "keine Unterschiede",
+		"validation error":         "Validierungsfehler",
+		"validation errors":        "Validierungsfehler",
+		"got":                      "erhalten",
+		"(root)":                   "(Wurzel)",
+		"USAGE":                    "AUFRUF",
+		"COMMANDS":                 "BEFEHLE",
+		"FLAGS":                    "OPTIONEN",
+		"EXAMPLES":                 "BEISPIELE",
+		"default":                  "Standard",
+		"Update available %s → %s": "Update verfügbar %s → %s",
+		"body truncated at %s":     "Body nach %s gekürzt",
+		"is required":              "ist erforderlich",
+		"must be at least %s":      "muss mindestens %s sein",
+		"must be at most %s":       "darf höchstens %s sein",
+		"No changes.":              "Keine Änderungen.",
+		"Plan: %d to add, %d to change, %d to destroy.": "Plan: %d hinzufügen, %d ändern, %d löschen.",
+		"cycle":                  "Zyklus",
+		"Less":                   "Weniger",
+		"More":                   "Mehr",
+		"… truncated (%s total)": "… gekürzt (%s insgesamt)",
+	},
+}
+
+// Registered locales and the active one, guarded by mu
+var (
+	locales = map[string]*Locale{"de": &German}
+	locale  *Locale // nil means English
+)
+
+// RegisterLocale makes l available to SetLocale under tag
+// Registering an existing tag replaces it
+func RegisterLocale(tag string, l Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+	locales[strings.ToLower(tag)] = &l
+}
+
+// SetLocale switches built-in messages, level names and timestamps to tag
+// Use "en" or "" to restore English; unknown tags return an error
+func SetLocale(tag string) error {
+	tag = strings.ToLower(tag)
+	mu.Lock()
+	defer mu.Unlock()
+	if tag == "" || tag == "en" {
+		locale = nil
+		return nil
+	}
+	l, ok := locales[tag]
+	if !ok {
+		return fmt.Errorf("unknown locale %q", tag)
+	}
+	locale = l
+	return nil
+}
+
+// tr translates a built-in message format with the active locale
+// Returns s unchanged when no translation exists
+func tr(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if locale != nil {
+		if t, ok := locale.Messages[s]; ok {
+			return t
+		}
+	}
+	return s
+}
+
+// levelName returns the upper case name of level in the active locale
+func levelName(level LogLevel) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if locale != nil {
+		if name, ok := locale.Levels[level]; ok {
+			return name
+		}
+	}
+	return strings.ToUpper(level.String())
+}
+
+// localTime formats t with layout, or the locale layout when layout is empty
+// Month and day name tokens of the layout render the locale's names
+func localTime(t time.Time, layout string) string {
+	mu.RLock()
+	l := locale
+	mu.RUnlock()
+
+	if layout == "" {
+		layout = DefaultTimeFormat
+		if l != nil && l.TimeFormat != "" {
+			layout = l.TimeFormat
+		}
+	}
+	if l == nil {
+		return t.Format(layout)
+	}
+	var b strings.Builder
+	for {
+		i, token := nameToken(layout)
+		if i < 0 {
+			b.WriteString(t.Format(layout))
+			return b.String()
+		}
+		b.WriteString(t.Format(layout[:i]))
+		var name string
+		switch token {
+		case "January", "Jan":
+			name = l.Months[t.Month()-1]
+		default:
+			name = l.Days[t.Weekday()]
+		}
+		if name == "" {
+			name = t.Format(token)
+		} else if len(token) == 3 {
+			name = string([]rune(name)[:min(3, utf8.RuneCountInString(name))])
+		}
+		b.WriteString(name)
+		layout = layout[i+len(token):]
+	}
+}
+
+// nameToken finds the first month or day name token in layout
+// Follows time.Format: "Jan" and "Mon" are only tokens when no lower case letter follows
+func nameToken(layout string) (int, string) {
+	for i := 0; i < len(layout); i++ {
+		for _, token := range [...]string{"January", "Monday", "Jan", "Mon"} {
+			if !strings.HasPrefix(layout[i:], token) {
+				continue
+			}
+			if rest := layout[i+len(token):]; len(token) == 3 && rest != "" && 'a' <= rest[0] && rest[0] <= 'z' {
+				continue
+			}
+			return i, token
+		}
+	}
+	return -1, ""
+}
@@ -0,0 +1,110 @@
+package aurora
+
+import (
+	"encoding"
+	"encoding/json"
+	"github.com/nwidger/jsoncolor"
+	"reflect"
+)
+
+// logMarshalErrors controls whether the non-E dump methods log failures
+// Guarded by the package mutex
+var logMarshalErrors = true
+
+// protoMarshaler renders protobuf messages as JSON
+// Nil until SetProtoMarshaler is called; guarded by the package mutex
+var protoMarshaler func(v any) ([]byte, error)
+
+// SetProtoMarshaler registers the function used to render protobuf messages
+// Keeps aurora free of a protobuf dependency; typically wraps protojson:
+//
+//	aurora.SetProtoMarshaler(func(v any) ([]byte, error) {
+//		return protojson.Marshal(v.(proto.Message))
+//	})
+func SetProtoMarshaler(fn func(v any) ([]byte, error)) {
+	mu.Lock()
+	defer mu.Unlock()
+	protoMarshaler = fn
+}
+
+// SetMarshalErrorLogging toggles logging of serialization failures
+// Applies to JSON, XML and TOML methods that do not return errors
+// The E variants such as JSONE always return errors to the caller instead
+func SetMarshalErrorLogging(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	logMarshalErrors = enabled
+}
+
+// marshalFailed logs err at Error level when marshal error logging is enabled
+// Internal helper used by the dump methods that swallow errors
+func (n *Notifier) marshalFailed(err error) {
+	mu.RLock()
+	enabled := logMarshalErrors
+	mu.RUnlock()
+	if err != nil && enabled {
+		n.Logf(ErrorLevel, "%v", err)
+	}
+}
+
+// encodable converts v into a value whose JSON form is meaningful to read
+// Protobuf messages, json.Marshaler and encoding.TextMarshaler implementations
+// are rendered through their own marshaling instead of their struct internals;
+// nil pointers become null without calling their methods. Only v itself is
+// converted: a message nested in a struct, map or slice is left to
+// encoding/json, which dumps its struct fields
+func encodable(v any) (any, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+	if isProtoMessage(v) {
+		mu.RLock()
+		marshal := protoMarshaler
+		mu.RUnlock()
+		if marshal != nil {
+			data, err := marshal(v)
+			return json.RawMessage(data), err
+		}
+	}
+
+	switch t := v.(type) {
+	case json.Marshaler:
+		data, err := t.MarshalJSON()
+		return json.RawMessage(data), err
+	case encoding.TextMarshaler:
+		text, err := t.MarshalText()
+		return string(text), err
+	}
+	return v, nil
+}
+
+// isProtoMessage reports whether v looks like a generated protobuf message
+// Detection relies on the ProtoReflect method all modern messages implement
+func isProtoMessage(v any) bool {
+	if v == nil {
+		return false
+	}
+	return reflect.ValueOf(v).MethodByName("ProtoReflect").IsValid()
+}
+
+// marshalJSON colorizes v as JSON with the given indentation
+// Values pass through encodable first so custom marshalers are honored
+// Depth and size limits from SetMaxDepth and SetMaxDumpBytes are applied,
+// as is the key order from SetKeyOrder
+func marshalJSON(v any, indent string) ([]byte, error) {
+	ev, err := encodable(v)
+	if err != nil {
+		return nil, err
+	}
+	if ev, err = limitDepth(ev); err != nil {
+		return nil, err
+	}
+	if ev, err = arrangeKeys(ev); err != nil {
+		return nil, err
+	}
+	data, err := jsoncolor.MarshalIndent(ev, "", indent)
+	if err != nil {
+		return nil, err
+	}
+	return limitDump(data), nil
+}
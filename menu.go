@@ -0,0 +1,64 @@
+package aurora
+
+import (
+	"errors"
+	"fmt"
+	"github.com/fatih/color"
+	"strconv"
+	"strings"
+)
+
+// ErrNoMenuItems is returned by Menu when there is nothing to choose from
+var ErrNoMenuItems = errors.New("aurora: menu has no items")
+
+// MenuItem is a single choice of a Menu
+// Action runs when the item is picked; a nil Action just returns
+type MenuItem struct {
+	Label  string
+	Action func() error
+}
+
+// Menu shows title and a numbered list of items and runs the one picked
+// Invalid answers print a warning and ask again; read errors end the menu
+// An error from the action is reported as a Failure and returned
+func (n *Notifier) Menu(title string, items []MenuItem) error {
+	if len(items) == 0 {
+		return ErrNoMenuItems
+	}
+	var b strings.Builder
+	b.WriteString(color.New(color.Bold).Sprint(title) + "\n")
+	digits := len(strconv.Itoa(len(items)))
+	for i, item := range items {
+		num := fmt.Sprintf("%*d)", digits, i+1)
+		fmt.Fprintf(&b, "  %s %s\n", paint(n.force(color.New(color.FgCyan)), num), item.Label)
+	}
+	n.mu.Lock()
+	n.output.route(ConsoleSink, []byte(n.colorMode.apply(b.String())), nil)
+	n.mu.Unlock()
+
+	for {
+		answer, err := n.prompt(fmt.Sprintf(tr("Choose 1-%d: "), len(items)))
+		if err != nil {
+			return err
+		}
+		choice, err := strconv.Atoi(strings.TrimSpace(answer))
+		if err != nil || choice < 1 || choice > len(items) {
+			n.Warn(tr("invalid choice %q"), answer)
+			continue
+		}
+
+		item := items[choice-1]
+		if item.Action == nil {
+			return nil
+		}
+		if err := item.Action(); err != nil {
+			n.Failure("%s: %v", item.Label, err)
+			return err
+		}
+		return nil
+	}
+}
+
+// Menu shows a numbered menu and runs the picked item using the default Notifier
+// A lightweight interactive mode for admin CLIs
+func Menu(title string, items []MenuItem) error { return Default.Menu(title, items) }
@@ -0,0 +1,85 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// meterWidth is the number of cells in a meter bar
+const meterWidth = 20
+
+// Meter fill ratios from which the bar turns yellow and red
+const (
+	meterWarn     = 0.7
+	meterCritical = 0.9
+)
+
+// Meter colors for normal, high and critical readings
+var (
+	meterOK   = color.New(color.FgGreen)
+	meterHigh = color.New(color.FgYellow)
+	meterFull = color.New(color.FgRed)
+)
+
+// Reading is a single instantaneous value shown by Meters
+type Reading struct {
+	Label string
+	Value float64
+	Max   float64
+}
+
+// Meter prints a single-line meter of value out of max, e.g. disk usage
+// The bar is green, yellow from 70% and red from 90%; unlike a Progress
+// it is a one-off reading and is not updated in place
+func (n *Notifier) Meter(label string, value, max float64) {
+	n.Meters([]Reading{{Label: label, Value: value, Max: max}})
+}
+
+// Meters prints several readings as a block with aligned labels and bars
+// e.g. memory, disk and quota of a host in one go
+func (n *Notifier) Meters(readings []Reading) {
+	width := 0
+	for _, r := range readings {
+		width = max(width, displayWidth(r.Label))
+	}
+	var b strings.Builder
+	for _, r := range readings {
+		b.WriteString(r.Label + strings.Repeat(" ", width-displayWidth(r.Label)+2))
+		b.WriteString(n.meterBar(r) + "\n")
+	}
+	n.writeBlock(b.String())
+}
+
+// meterBar renders the bar, percentage and amounts of r
+// A reading without a meaningful ratio, such as NaN, shows as empty
+func (n *Notifier) meterBar(r Reading) string {
+	ratio := 0.0
+	if r.Max > 0 {
+		ratio = min(max(r.Value/r.Max, 0), 1)
+	}
+	if math.IsNaN(ratio) {
+		ratio = 0
+	}
+	c := meterOK
+	switch {
+	case ratio >= meterCritical:
+		c = meterFull
+	case ratio >= meterWarn:
+		c = meterHigh
+	}
+	filled := int(ratio*meterWidth + 0.5)
+	bar := paint(n.force(c), strings.Repeat("█", filled)) + strings.Repeat("░", meterWidth-filled)
+	amount := strconv.FormatFloat(r.Value, 'f', -1, 64) + "/" + strconv.FormatFloat(r.Max, 'f', -1, 64)
+	return fmt.Sprintf("%s %3.0f%% %s", bar, ratio*100, amount)
+}
+
+// Meter prints a single reading using the default Notifier
+// Disk usage, quotas and similar levels
+func Meter(label string, value, max float64) { Default.Meter(label, value, max) }
+
+// Meters prints several readings using the default Notifier
+// Aligned block of meters
+func Meters(readings []Reading) { Default.Meters(readings) }
@@ -0,0 +1,168 @@
+package aurora
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metricInterval is how often metric values are logged off-TTY, set with SetMetricInterval
+// Guarded by the package mutex
+var metricInterval = 10 * time.Second
+
+// metric is a named value shown by Counter and Gauge
+type metric struct {
+	name  string
+	value float64
+}
+
+// metricSet holds the metrics of a Notifier family and their status line
+// Shared between derived Notifiers and guarded by their mutex
+type metricSet struct {
+	metrics []*metric
+	handle  liveLine  // Status line with every metric on terminals
+	logged  time.Time // When values were last written as an entry off-TTY
+}
+
+// newMetricSet creates an empty metricSet
+func newMetricSet() *metricSet {
+	return &metricSet{}
+}
+
+// Counter is a monotonically increasing count shown in the metrics status line
+type Counter struct {
+	n *Notifier
+	m *metric
+}
+
+// Gauge is a value that goes up and down shown in the metrics status line
+type Gauge struct {
+	n *Notifier
+	m *metric
+}
+
+// Counter creates a counter named name, starting at zero
+// Terminals show every metric in one live status line; other writers
+// get an Info entry at most every metric interval. Close logs final values
+func (n *Notifier) Counter(name string) *Counter {
+	return &Counter{n: n, m: n.addMetric(name)}
+}
+
+// Gauge creates a gauge named name, starting at zero
+// Shown and logged together with counters
+func (n *Notifier) Gauge(name string) *Gauge {
+	return &Gauge{n: n, m: n.addMetric(name)}
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() { c.Add(1) }
+
+// Add adds delta to the counter; negative deltas are ignored
+func (c *Counter) Add(delta int64) {
+	if delta > 0 {
+		c.n.updateMetric(c.m, func(v float64) float64 { return v + float64(delta) })
+	}
+}
+
+// Value returns the current count
+func (c *Counter) Value() int64 {
+	c.n.mu.Lock()
+	defer c.n.mu.Unlock()
+	return int64(c.m.value)
+}
+
+// Set replaces the gauge value
+func (g *Gauge) Set(v float64) {
+	g.n.updateMetric(g.m, func(float64) float64 { return v })
+}
+
+// Value returns the current gauge value
+func (g *Gauge) Value() float64 {
+	g.n.mu.Lock()
+	defer g.n.mu.Unlock()
+	return g.m.value
+}
+
+// addMetric registers a metric named name with the Notifier family
+func (n *Notifier) addMetric(name string) *metric {
+	m := &metric{name: name}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.metrics.metrics = append(n.metrics.metrics, m)
+	return m
+}
+
+// updateMetric applies fn to the value of m and refreshes the status
+// Off-TTY the values are logged once the metric interval has passed
+func (n *Notifier) updateMetric(m *metric, fn func(float64) float64) {
+	mu.RLock()
+	interval := metricInterval
+	mu.RUnlock()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	m.value = fn(m.value)
+	set := n.metrics
+	if n.output.terminal() {
+		n.output.live.set(&set.handle, n.liveText(InfoLevel, set.String()))
+		return
+	}
+	now := time.Now()
+	if set.logged.IsZero() {
+		set.logged = now // The first interval starts with the first update
+		return
+	}
+	if now.Sub(set.logged) >= interval {
+		set.logged = now
+		n.emit(n.newEntry(InfoLevel, set.String()))
+	}
+}
+
+// metricsReport clears the status line and logs the final metric values
+// Internal helper for Close; callers must hold the mutex
+func (n *Notifier) metricsReport() {
+	set := n.metrics
+	if len(set.metrics) == 0 {
+		return
+	}
+	n.output.live.remove(&set.handle)
+	n.write(n.newEntry(NoticeLevel, set.String()))
+	set.logged = time.Time{}
+}
+
+// String renders every metric as "name: value", joined by commas
+func (s *metricSet) String() string {
+	parts := make([]string, len(s.metrics))
+	for i, m := range s.metrics {
+		parts[i] = m.name + ": " + strconv.FormatFloat(m.value, 'f', -1, 64)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// values returns the metric values by name for Stats
+func (s *metricSet) values() map[string]float64 {
+	if len(s.metrics) == 0 {
+		return nil
+	}
+	values := make(map[string]float64, len(s.metrics))
+	for _, m := range s.metrics {
+		values[m.name] = m.value
+	}
+	return values
+}
+
+// NewCounter creates a counter using the default Notifier
+// Named to avoid clashing with the Counter type
+func NewCounter(name string) *Counter { return Default.Counter(name) }
+
+// NewGauge creates a gauge using the default Notifier
+// Named to avoid clashing with the Gauge type
+func NewGauge(name string) *Gauge { return Default.Gauge(name) }
+
+// SetMetricInterval sets how often counters and gauges are logged off-TTY
+// Terminals update the status line on every change regardless
+func SetMetricInterval(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	metricInterval = d
+}
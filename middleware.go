@@ -0,0 +1,61 @@
+package aurora
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Middleware processes an entry before filters and formatting
+// Returns the entries to write: none suppresses e, a modified copy
+// rewrites it and several duplicate it
+type Middleware func(e Entry) []Entry
+
+// Use appends mw to the middleware chain of subsequent entries
+// Each middleware sees every entry the previous one returned; the chain
+// runs under the Notifier lock and must not log through the same family
+func (n *Notifier) Use(mw ...Middleware) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	// Copy so Notifiers derived earlier keep their own chain
+	n.middleware = append(slices.Clip(n.middleware), mw...)
+}
+
+// Redact returns a Middleware replacing matches of pattern in messages
+// The replacement may refer to submatches like regexp.ReplaceAllString
+// Panics on an invalid pattern
+func Redact(pattern, replacement string) Middleware {
+	re := regexp.MustCompile(pattern)
+	return func(e Entry) []Entry {
+		e.Message = re.ReplaceAllString(e.Message, replacement)
+		return []Entry{e}
+	}
+}
+
+// through runs e through the middleware chain
+// Entries keep the sinks and rendering flags of e; removed ones are counted as dropped
+// Internal helper; callers must hold the mutex
+func (n *Notifier) through(e entry) []entry {
+	current := []Entry{e.Entry}
+	for _, mw := range n.middleware {
+		var next []Entry
+		for _, in := range current {
+			next = append(next, mw(in)...)
+		}
+		current = next
+	}
+	if len(current) == 0 {
+		n.dropped.count++
+		n.stats.dropped++
+		return nil
+	}
+	out := make([]entry, len(current))
+	for i, public := range current {
+		out[i] = e
+		out[i].Entry = public
+	}
+	return out
+}
+
+// Use appends middleware to the default Notifier
+// Rewrites, drops or duplicates entries before they print
+func Use(mw ...Middleware) { Default.Use(mw...) }
@@ -0,0 +1,39 @@
+package aurora
+
+import "github.com/fatih/color"
+
+// Minimal is the terse theme applied by WithMinimal
+// Single characters without brackets; routine levels are dimmed
+var Minimal = Theme{
+	Symbols: map[LogLevel]string{
+		TraceLevel:    "·",
+		DebugLevel:    "·",
+		InfoLevel:     "·",
+		NoticeLevel:   "·",
+		WarnLevel:     "!",
+		ErrorLevel:    "✗",
+		AlertLevel:    "!",
+		CriticalLevel: "✗",
+	},
+	Colors: map[LogLevel]*color.Color{
+		TraceLevel:    color.New(color.Faint),
+		DebugLevel:    color.New(color.Faint),
+		InfoLevel:     color.New(color.Faint),
+		NoticeLevel:   color.New(color.Faint),
+		WarnLevel:     color.New(color.FgYellow),
+		ErrorLevel:    color.New(color.FgRed),
+		AlertLevel:    color.New(color.FgYellow),
+		CriticalLevel: color.New(color.FgRed),
+	},
+	Spinner: "line",
+	Icons:   TaskIcons{Success: "·", Failure: "✗"},
+}
+
+// WithMinimal applies the Minimal theme and drops Logf timestamps
+// For tools whose output is wrapped by other tools and must stay terse
+func WithMinimal() Option {
+	return func(n *Notifier) {
+		WithTheme(Minimal)(n)
+		n.stampless = true
+	}
+}
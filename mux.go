@@ -0,0 +1,116 @@
+package aurora
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mux interleaves the output of several named streams like docker-compose
+// Each line is shown after a colored name column aligned across streams
+// Lines are written whole, so concurrent streams never mix within a line
+type Mux struct {
+	n     *Notifier
+	mu    sync.Mutex // Protects width and errs
+	width int        // Widest stream name seen so far
+	wg    sync.WaitGroup
+	errs  []error
+}
+
+// Mux creates a multiplexer writing through the Notifier
+// Filters, sinks and Hold apply to its lines like any other entry
+func (n *Notifier) Mux() *Mux {
+	return &Mux{n: n}
+}
+
+// Stream returns a writer whose lines appear under name
+// Create every stream before writing to keep the column stable;
+// Close flushes a trailing partial line
+func (m *Mux) Stream(name string) io.WriteCloser {
+	m.mu.Lock()
+	m.width = max(m.width, displayWidth(name))
+	m.mu.Unlock()
+	return newLineWriter(func(line string) { m.line(name, line) })
+}
+
+// Go runs fn in its own goroutine with a stream named name
+// Errors are collected and returned by Wait; results are recorded for WriteJUnit
+func (m *Mux) Go(name string, fn func(w io.Writer) error) {
+	w := m.Stream(name)
+	m.wg.Add(1)
+	m.n.started(name)
+	start := time.Now()
+	go func() {
+		defer m.wg.Done()
+		err := fn(w)
+		w.Close()
+		m.n.Record(name, time.Since(start), err)
+		if err != nil {
+			m.fail(fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+}
+
+// Command starts cmd with stdout and stderr shown under name
+// The command must not have been started; use Wait for its exit status
+func (m *Mux) Command(name string, cmd *exec.Cmd) error {
+	w := m.Stream(name)
+	cmd.Stdout, cmd.Stderr = w, w
+	if err := cmd.Start(); err != nil {
+		w.Close()
+		return err
+	}
+	m.n.started(name)
+	m.wg.Add(1)
+	start := time.Now()
+	go func() {
+		defer m.wg.Done()
+		err := cmd.Wait()
+		w.Close()
+		m.n.Record(name, time.Since(start), err)
+		if err != nil {
+			m.fail(fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until every Go function and Command has finished
+// Returns their errors joined, each prefixed with the stream name
+func (m *Mux) Wait() error {
+	m.wg.Wait()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return errors.Join(m.errs...)
+}
+
+// fail records a stream error for Wait
+func (m *Mux) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs = append(m.errs, err)
+}
+
+// line writes a single line under the padded, colored name column
+func (m *Mux) line(name, line string) {
+	m.mu.Lock()
+	pad := m.width - displayWidth(name)
+	m.mu.Unlock()
+
+	n := m.n
+	label := name + strings.Repeat(" ", pad) + " |"
+	e := n.newEntry(NoLevel, paint(n.force(PrefixColor(name)), label)+" "+line)
+	e.plain = true
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.emit(e)
+}
+
+// NewMux creates a multiplexer writing through the default Notifier
+// Quick docker-compose style output for concurrent jobs
+func NewMux() *Mux { return Default.Mux() }
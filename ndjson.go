@@ -0,0 +1,47 @@
+package aurora
+
+import (
+	"bytes"
+	"github.com/nwidger/jsoncolor"
+	"io"
+	"strings"
+)
+
+// NDJSON prints each value received from ch as one line of colorized JSON
+// Values are printed as they arrive and NDJSON returns once ch is closed
+// Consuming one value at a time gives natural backpressure to producers
+func (n *Notifier) NDJSON(ch <-chan any) {
+	for v := range ch {
+		data, err := marshalJSON(v, IndentNone)
+		if err != nil {
+			n.marshalFailed(err)
+			continue
+		}
+		n.writeBlock(string(data) + "\n")
+	}
+}
+
+// NDJSONWriter returns a writer that colorizes newline-delimited JSON
+// Each complete line is printed as it is written; invalid JSON passes through as is
+// Close flushes a final line that lacks its newline
+func (n *Notifier) NDJSONWriter() io.WriteCloser {
+	return newLineWriter(func(line string) {
+		if strings.TrimSpace(line) == "" {
+			return
+		}
+		var out bytes.Buffer
+		if err := jsoncolor.NewFormatter().Format(&out, []byte(line)); err != nil {
+			n.writeBlock(line + "\n")
+			return
+		}
+		n.writeBlock(out.String() + "\n")
+	})
+}
+
+// NDJSON prints values from ch as JSON lines using the default Notifier
+// Useful when tailing streaming API responses
+func NDJSON(ch <-chan any) { Default.NDJSON(ch) }
+
+// NDJSONWriter returns a colorizing NDJSON writer bound to the default Notifier
+// Suitable as the destination of io.Copy from a streaming body
+func NDJSONWriter() io.WriteCloser { return Default.NDJSONWriter() }
@@ -0,0 +1,6 @@
+//go:build !race
+
+package aurora
+
+// raceEnabled reports whether tests run under the race detector
+const raceEnabled = false
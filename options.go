@@ -0,0 +1,197 @@
+package aurora
+
+import (
+	"bufio"
+	"github.com/fatih/color"
+	"io"
+	"maps"
+	"time"
+)
+
+// Option configures a Notifier
+// Options only touch the Notifier they are applied to, never package globals
+type Option func(*Notifier)
+
+// ColorMode decides whether a Notifier emits color escape sequences
+type ColorMode int
+
+const (
+	ColorAuto   ColorMode = iota // Follow terminal detection in the color package
+	ColorAlways                  // Always color level output, e.g. when piping to a pager
+	ColorNever                   // Strip all color from the Notifier's output
+)
+
+// apply enforces the mode on rendered output
+// Only ColorNever alters text, by stripping escape sequences
+func (m ColorMode) apply(s string) string {
+	if m.strips() {
+		return StripANSI(s)
+	}
+	return s
+}
+
+// strips reports whether escape sequences are removed from output
+// True for ColorNever and whenever porcelain mode is on
+func (m ColorMode) strips() bool {
+	return m == ColorNever || Porcelain()
+}
+
+// WithOptions returns a derived Notifier with opts applied
+// The parent and package-level configuration are left untouched,
+// which lets libraries tweak output without affecting their callers
+func (n *Notifier) WithOptions(opts ...Option) *Notifier {
+	n.mu.Lock()
+	child := n.derive()
+	n.mu.Unlock()
+	for _, opt := range opts {
+		opt(child)
+	}
+	return child
+}
+
+// Theme bundles symbol and color overrides for the levels
+// Levels missing from either map keep their defaults
+type Theme struct {
+	Symbols map[LogLevel]string
+	Colors  map[LogLevel]*color.Color
+	Spinner string    // Name of a registered spinner style, "dots" when empty
+	Icons   TaskIcons // Icons spinners finish with, IconSuccess/IconError when empty
+}
+
+// WithCaller reports the calling file and line before each message
+// Only the base name of the file is shown to keep lines short
+func WithCaller() Option {
+	return func(n *Notifier) { n.caller = true }
+}
+
+// WithColorMode sets whether the Notifier colors its output
+// ColorAlways forces level colors, ColorNever strips every escape sequence
+func WithColorMode(mode ColorMode) Option {
+	return func(n *Notifier) { n.colorMode = mode }
+}
+
+// WithExitCodes sets the codes ExitCode returns per level threshold
+// e.g. {ErrorLevel: 1} makes warnings exit cleanly and errors fail
+func WithExitCodes(codes map[LogLevel]int) Option {
+	return func(n *Notifier) { n.exitCodes = maps.Clone(codes) }
+}
+
+// WithBurstFolding folds repeats of an entry within window into one line
+// The first entry prints at once; a repeat count follows when the window
+// closes. Digits are ignored when comparing, so "retry 3" repeats "retry 2"
+func WithBurstFolding(window time.Duration) Option {
+	return func(n *Notifier) { n.foldWindow = window }
+}
+
+// WithInput sets where interactive helpers such as Danger read answers
+// Defaults to os.Stdin; handy for scripted input in tests
+func WithInput(r io.Reader) Option {
+	return func(n *Notifier) { n.input = bufio.NewReader(r) }
+}
+
+// WithJSONFormat writes entries as single line JSON objects
+// Keys are time, level, msg and, when set, prefix, caller, worker, tag and
+// fields, the last holding WithFields values as an object; dumps are unaffected
+func WithJSONFormat() Option {
+	return func(n *Notifier) { n.jsonFormat = true }
+}
+
+// WithLevel sets the minimum level the Notifier writes
+// Entries below level are skipped; NoLevel output is never filtered
+func WithLevel(level LogLevel) Option {
+	return func(n *Notifier) { n.level = level }
+}
+
+// WithLevelColor overrides the color of a single level
+// Use nil to print that level without color
+func WithLevelColor(level LogLevel, c *color.Color) Option {
+	return func(n *Notifier) {
+		n.colors = maps.Clone(n.colors)
+		if n.colors == nil {
+			n.colors = make(map[LogLevel]*color.Color)
+		}
+		n.colors[level] = c
+	}
+}
+
+// WithRedactedHeaders sets the headers whose values Curl masks
+// Replaces the default list of Authorization, Cookie and similar headers
+func WithRedactedHeaders(names ...string) Option {
+	return func(n *Notifier) { n.redacted = append([]string{}, names...) }
+}
+
+// WithSymbol overrides the symbol of a single level
+// Unlike SetSymbol this does not affect other Notifiers
+func WithSymbol(level LogLevel, symbol string) Option {
+	return func(n *Notifier) {
+		n.symbols = maps.Clone(n.symbols)
+		if n.symbols == nil {
+			n.symbols = make(map[LogLevel]string)
+		}
+		n.symbols[level] = symbol
+	}
+}
+
+// WithVerbose sets whether Verbose blocks are written immediately
+// Hidden blocks are still kept for DumpOnError
+func WithVerbose(enabled bool) Option {
+	return func(n *Notifier) { n.verbose = enabled }
+}
+
+// WithPrefixColors gives every prefix its own stable color
+// Interleaved output from With("api") and With("db") becomes easy to tell apart
+func WithPrefixColors() Option {
+	return func(n *Notifier) { n.prefixHue = true }
+}
+
+// WithTheme applies the symbol and color overrides of t
+// Combines with WithSymbol and WithLevelColor; the last option wins
+func WithTheme(t Theme) Option {
+	return func(n *Notifier) {
+		if len(t.Symbols) > 0 {
+			n.symbols = maps.Clone(n.symbols)
+			if n.symbols == nil {
+				n.symbols = make(map[LogLevel]string)
+			}
+			maps.Copy(n.symbols, t.Symbols)
+		}
+		if len(t.Colors) > 0 {
+			n.colors = maps.Clone(n.colors)
+			if n.colors == nil {
+				n.colors = make(map[LogLevel]*color.Color)
+			}
+			maps.Copy(n.colors, t.Colors)
+		}
+		if t.Spinner != "" {
+			n.spinner = t.Spinner
+		}
+		if t.Icons.Success != "" {
+			n.icons.Success = t.Icons.Success
+		}
+		if t.Icons.Failure != "" {
+			n.icons.Failure = t.Icons.Failure
+		}
+	}
+}
+
+// WithReplacePrefix replaces the prefix chain instead of appending to it
+// e.g. n.WithOptions(WithReplacePrefix("db")); an empty prefix removes it
+func WithReplacePrefix(prefix string) Option {
+	return func(n *Notifier) {
+		n.chain = nil
+		if prefix != "" {
+			n.chain = []string{prefix}
+		}
+		n.prefix = prefix
+	}
+}
+
+// WithTimeFormat sets the timestamp layout used by Logf
+// Accepts any time.Format layout string
+func WithTimeFormat(layout string) Option {
+	return func(n *Notifier) { n.timeFormat = layout }
+}
+
+// WithOptions returns a Notifier derived from the default one with opts applied
+// Scoped configuration without touching globals
+func WithOptions(opts ...Option) *Notifier { return Default.WithOptions(opts...) }
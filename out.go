@@ -0,0 +1,22 @@
+package aurora
+
+// Out writes program output: the formatted text and a newline, nothing else
+// Never colored, leveled, filtered, held or copied to sinks, and always
+// on the Notifier's own writer whatever SetStreams routes elsewhere, so
+// stdout stays machine-readable while diagnostics are decorated
+func (n *Notifier) Out(format string, args ...any) {
+	line := []byte(sprintf(format, args) + "\n")
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s := n.output
+	if s.live != nil {
+		s.live.before()
+		defer s.live.after()
+	}
+	s.console().Write(line)
+}
+
+// Out writes program output to the default Notifier's writer
+// The counterpart of the logging methods for results meant for pipes
+func Out(format string, args ...any) { Default.Out(format, args...) }
@@ -0,0 +1,213 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// switchWriter holds the destination of a Notifier family
+// Derived Notifiers share it, so swapping redirects all of them
+// Reads and swaps happen under the Notifier mutex
+type switchWriter struct {
+	w          io.Writer
+	held       *bytes.Buffer  // Console output collected while on hold, nil otherwise
+	sinks      []sink         // Additional destinations added with AddSink or MirrorPlain
+	entrySinks []entrySink    // Structured destinations added with AddEntrySink
+	bytes      int64          // Bytes accepted for the console, reported by Stats
+	live       *renderer      // Live region on the console, nil for internal captures
+	limit      *rateLimit     // Console rate limit set with SetRateLimit, nil when off
+	stall      *timeoutWriter // Console write deadline set with SetWriteTimeout, nil when off
+	async      *asyncWriter   // Background console writer set with SetAsync, nil when off
+	closed     bool           // Whether Close ran, so later entries are reported as misuse
+	events     *json.Encoder  // Event stream set with SetEvents, nil when off
+	streams    *StreamPolicy  // Level to stream mapping set with SetStreams, nil when off
+	target     io.Writer      // Console writer of the entry being routed to stderr, nil otherwise
+
+	// Time source set with WithCoarseTime, nil for time.Now; loaded without the lock
+	clock atomic.Pointer[coarseClock]
+}
+
+// newSwitchWriter creates the destination of a Notifier family guarded by mu
+func newSwitchWriter(w io.Writer, mu *sync.Mutex) *switchWriter {
+	s := &switchWriter{w: w}
+	s.live = &renderer{mu: mu, s: s}
+	return s
+}
+
+// Write sends p to every destination
+func (s *switchWriter) Write(p []byte) (int, error) {
+	return s.route(AllSinks, p, nil)
+}
+
+// mirrored reports whether any sink wants plain text
+func (s *switchWriter) mirrored() bool {
+	for _, sk := range s.sinks {
+		if sk.plain {
+			return true
+		}
+	}
+	return false
+}
+
+// route sends p to the destinations matching tags
+// Plain sinks receive plain, or p stripped of ANSI codes when it is nil
+// Holding only delays the console; other sinks are written at once
+// Returns the result of the console write when it is included
+func (s *switchWriter) route(tags SinkTag, p []byte, plain []byte) (int, error) {
+	for _, sk := range s.sinks {
+		if sk.tag&tags == 0 {
+			continue
+		}
+		if !sk.plain {
+			sk.w.Write(p)
+			continue
+		}
+		if plain == nil {
+			plain = []byte(StripANSI(string(p)))
+		}
+		sk.w.Write(plain)
+	}
+	if tags&ConsoleSink == 0 {
+		return len(p), nil
+	}
+	if s.limit != nil && s.held == nil {
+		ok, notice := s.limit.allow(p, time.Now())
+		if notice != "" {
+			s.write([]byte(notice))
+		}
+		if !ok {
+			return len(p), nil
+		}
+	}
+	n, err := s.write(p)
+	s.bytes += int64(n)
+	return n, err
+}
+
+// console returns the writer console output goes to
+// The stderr stream while routing to it, then the async queue or
+// write deadline wrapper when one is set, w otherwise
+func (s *switchWriter) console() io.Writer {
+	switch {
+	case s.target != nil:
+		return s.target
+	case s.async != nil:
+		return s.async
+	case s.stall != nil:
+		return s.stall
+	}
+	return s.w
+}
+
+// write puts p on the console, around the live region or into the hold buffer
+// Output routed to stderr is never held, so it cannot be replayed onto stdout
+func (s *switchWriter) write(p []byte) (int, error) {
+	if s.held != nil && s.target == nil {
+		return s.held.Write(p)
+	}
+	if s.live == nil {
+		return s.console().Write(p)
+	}
+	s.live.before()
+	defer s.live.after()
+	return s.console().Write(p)
+}
+
+// terminal reports whether writes currently reach an animated terminal
+// Held output is replayed later and CI logs keep every frame, so in-place
+// updates are not safe in either case
+func (s *switchWriter) terminal() bool {
+	return s.held == nil && animated() && isTerminal(s.w)
+}
+
+// Discard drops everything collected since Hold and resumes normal output
+// Use on success when a build or CLI run should stay silent
+func (n *Notifier) Discard() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.held = nil
+}
+
+// Hold starts collecting output in memory instead of writing it
+// Nothing appears until Release or Discard decides its fate
+// Applies to the Notifier and those derived from it
+func (n *Notifier) Hold() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.output.held == nil {
+		n.output.held = &bytes.Buffer{}
+	}
+}
+
+// Release writes everything collected since Hold and resumes normal output
+// Use on failure to show the full log that led up to it
+func (n *Notifier) Release() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if held := n.output.held; held != nil {
+		n.output.held = nil
+		n.output.console().Write(held.Bytes())
+	}
+}
+
+// SetOutput redirects the Notifier and those derived from it to w
+// Uses os.Stdout if writer is nil; safe to call while logging
+func (n *Notifier) SetOutput(w io.Writer) {
+	n.SwapOutput(w)
+}
+
+// Destination returns the writer the Notifier family writes to
+// The one given to New or the last SetOutput, not any StreamPolicy writer
+func (n *Notifier) Destination() io.Writer {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.output.w
+}
+
+// SwapOutput redirects output like SetOutput and returns the previous writer
+// Lets tests capture a shared Notifier and restore it afterwards
+func (n *Notifier) SwapOutput(w io.Writer) io.Writer {
+	if w == nil {
+		w = os.Stdout
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	old := n.output.w
+	n.output.w = w
+	if stall := n.output.stall; stall != nil {
+		stall.stop()
+		n.output.stall = newTimeoutWriter(w, stall.timeout)
+		n.output.stall.dropped = stall.dropped
+	}
+	if async := n.output.async; async != nil {
+		async.stop()
+		n.output.async = newAsyncWriter(w, cap(async.queue))
+	}
+	return old
+}
+
+// Discard drops output held by the default Notifier
+// Quiet success for CLI tools
+func Discard() { Default.Discard() }
+
+// Hold starts collecting output of the default Notifier in memory
+// Pair with Release on failure or Discard on success
+func Hold() { Default.Hold() }
+
+// Release writes output held by the default Notifier
+// Full logs when something went wrong
+func Release() { Default.Release() }
+
+// SetOutput redirects the default Notifier to w
+// Affects every Notifier derived from it with With or WithOptions
+func SetOutput(w io.Writer) { Default.SetOutput(w) }
+
+// SwapOutput redirects the default Notifier and returns the previous writer
+// Pair with a deferred SwapOutput to restore it
+func SwapOutput(w io.Writer) io.Writer { return Default.SwapOutput(w) }
@@ -0,0 +1,67 @@
+package aurora
+
+import "fmt"
+
+// phaseScale is the parent total used once a Progress has phases
+// Permille keeps the aggregate smooth without floating point state
+const phaseScale = 1000
+
+// Phase is a weighted part of a Progress reporting its own percentage
+// The parent shows the weighted sum of all phases, so a long download
+// followed by a quick verify does not make the overall bar jump
+type Phase struct {
+	p       *Progress
+	name    string
+	weight  float64
+	percent float64
+}
+
+// phaseUnit shows phased progress amounts as percentages
+var phaseUnit = Unit{Format: func(v int64) string {
+	return fmt.Sprintf("%.1f%%", float64(v)*100/phaseScale)
+}}
+
+// Phase adds a weighted part to the progress, e.g. Phase("download", 70)
+// Declare every phase before reporting so weights are known up front;
+// the progress completes once all phases are done
+func (p *Progress) Phase(name string, weight float64) *Phase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.phases) == 0 {
+		p.total, p.current, p.unit = phaseScale, 0, phaseUnit
+	}
+	ph := &Phase{p: p, name: name, weight: max(weight, 0)}
+	p.phases = append(p.phases, ph)
+	return ph
+}
+
+// Set reports the phase as percent complete, clamped to 0–100
+func (ph *Phase) Set(percent float64) {
+	p := ph.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ph.percent = min(max(percent, 0), 100)
+	p.active = ph.name
+
+	var sum, weights float64
+	complete := true
+	for _, other := range p.phases {
+		sum += other.weight * other.percent
+		weights += other.weight
+		complete = complete && other.percent >= 100
+	}
+	if weights > 0 {
+		p.update(int64(sum / weights / 100 * phaseScale))
+	}
+	if complete && !p.done {
+		p.done = true
+		p.current = p.total
+		p.announce(true)
+		p.render(true)
+	}
+}
+
+// Done marks the phase as complete
+func (ph *Phase) Done() {
+	ph.Set(100)
+}
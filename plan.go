@@ -0,0 +1,88 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"strings"
+)
+
+// changeKind is the action a change performs
+type changeKind int
+
+const (
+	changeAdd changeKind = iota
+	changeUpdate
+	changeDelete
+)
+
+// changeMarks holds the sign and color of each change kind
+var changeMarks = map[changeKind]struct {
+	sign  string
+	color *color.Color
+}{
+	changeAdd:    {"+", color.New(color.FgGreen)},
+	changeUpdate: {"~", color.New(color.FgYellow)},
+	changeDelete: {"-", color.New(color.FgRed)},
+}
+
+// change is a single entry of a ChangeSet
+type change struct {
+	kind        changeKind
+	resource    string
+	description string
+}
+
+// ChangeSet collects the adds, changes and deletes a command will apply
+// Render it with Notifier.Plan; the zero value is an empty plan
+type ChangeSet struct {
+	changes []change
+}
+
+// Add registers a resource to be created
+func (cs *ChangeSet) Add(resource, description string) {
+	cs.changes = append(cs.changes, change{changeAdd, resource, description})
+}
+
+// Change registers a resource to be updated in place
+func (cs *ChangeSet) Change(resource, description string) {
+	cs.changes = append(cs.changes, change{changeUpdate, resource, description})
+}
+
+// Delete registers a resource to be destroyed
+func (cs *ChangeSet) Delete(resource, description string) {
+	cs.changes = append(cs.changes, change{changeDelete, resource, description})
+}
+
+// Plan prints cs terraform-style: "+" green, "~" yellow and "-" red,
+// descriptions aligned after the resources, then a summary of the counts
+// e.g. "Plan: 1 to add, 2 to change, 0 to destroy."
+func (n *Notifier) Plan(cs *ChangeSet) {
+	if len(cs.changes) == 0 {
+		n.writeBlock(tr("No changes.") + "\n")
+		return
+	}
+	width := 0
+	for _, c := range cs.changes {
+		width = max(width, displayWidth(c.resource))
+	}
+	counts := make(map[changeKind]int)
+	faint := n.force(color.New(color.Faint))
+	var b strings.Builder
+	for _, c := range cs.changes {
+		counts[c.kind]++
+		mark := changeMarks[c.kind]
+		line := "  " + paint(n.force(mark.color), mark.sign+" "+c.resource)
+		if c.description != "" {
+			line += strings.Repeat(" ", width-displayWidth(c.resource)+2) + paint(faint, c.description)
+		}
+		b.WriteString(line + "\n")
+	}
+	summary := fmt.Sprintf(tr("Plan: %d to add, %d to change, %d to destroy."),
+		counts[changeAdd], counts[changeUpdate], counts[changeDelete])
+	b.WriteString("\n" + paint(n.force(color.New(color.Bold)), summary) + "\n")
+	n.writeBlock(b.String())
+}
+
+// Plan prints a change set using the default Notifier
+// Shows what an apply step is about to do
+func Plan(cs *ChangeSet) { Default.Plan(cs) }
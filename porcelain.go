@@ -0,0 +1,52 @@
+package aurora
+
+import (
+	"os"
+	"slices"
+	"sync"
+)
+
+// porcelain overrides porcelain detection when set, guarded by mu
+var porcelain *bool
+
+// completionVariables are set by shells while running a completion command
+var completionVariables = []string{"COMP_LINE", "COMP_POINT", "_ARGCOMPLETE"}
+
+// porcelainArgs mark a run whose output is parsed by another program;
+// "__complete" is the hidden completion command of cobra based CLIs
+var porcelainArgs = []string{"--porcelain", "__complete", "__completeNoDesc"}
+
+// porcelainDetected reports whether the process looks like it runs for
+// a completion script or with --porcelain, decided once per process
+var porcelainDetected = sync.OnceValue(func() bool {
+	for _, name := range completionVariables {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return slices.ContainsFunc(os.Args[min(len(os.Args), 1):], func(arg string) bool {
+		return slices.Contains(porcelainArgs, arg)
+	})
+})
+
+// SetPorcelain turns porcelain mode on or off for every Notifier
+// Porcelain output has no symbols, timestamps, colors or animations,
+// which keeps it parseable; overrides detection of completion scripts
+// and a --porcelain argument
+func SetPorcelain(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	porcelain = &enabled
+}
+
+// Porcelain reports whether porcelain mode is on
+// Lets a CLI drop its own decorations, e.g. table borders, as well
+func Porcelain() bool {
+	mu.RLock()
+	override := porcelain
+	mu.RUnlock()
+	if override != nil {
+		return *override
+	}
+	return porcelainDetected()
+}
@@ -0,0 +1,61 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"hash/fnv"
+	"strings"
+)
+
+// prefixPalette holds the colors assigned to prefixes by hash
+// Level colors for warnings and errors are left out to avoid confusion
+var prefixPalette = []*color.Color{
+	color.New(color.FgHiCyan),
+	color.New(color.FgHiMagenta),
+	color.New(color.FgHiBlue),
+	color.New(color.FgCyan),
+	color.New(color.FgMagenta),
+	color.New(color.FgBlue),
+	color.New(color.FgHiGreen),
+	color.New(color.FgGreen),
+}
+
+// prefixColors holds colors assigned with SetPrefixColor, guarded by mu
+var prefixColors = make(map[string]*color.Color)
+
+// PrefixColor returns the color used for prefix
+// Manual assignments win, otherwise a palette color is picked by hash
+// so the same prefix gets the same color in every run
+func PrefixColor(prefix string) *color.Color {
+	mu.RLock()
+	c, ok := prefixColors[prefix]
+	mu.RUnlock()
+	if ok {
+		return c
+	}
+	h := fnv.New32a()
+	h.Write([]byte(prefix))
+	return prefixPalette[h.Sum32()%uint32(len(prefixPalette))]
+}
+
+// SetPrefixColor assigns c to prefix instead of the hashed palette color
+// Use nil to remove the assignment again
+func SetPrefixColor(prefix string, c *color.Color) {
+	mu.Lock()
+	defer mu.Unlock()
+	if c == nil {
+		delete(prefixColors, prefix)
+		return
+	}
+	prefixColors[prefix] = c
+}
+
+// paintLead colors lead with c except for the prefix, which gets its own color
+func (n *Notifier) paintLead(c *color.Color, lead, prefix string) string {
+	tag := "[" + prefix + "]"
+	i := strings.Index(lead, tag)
+	if i < 0 {
+		return paint(c, lead)
+	}
+	pc := n.force(PrefixColor(prefix))
+	return paint(c, lead[:i]) + paint(pc, tag) + paint(c, lead[i+len(tag):])
+}
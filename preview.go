@@ -0,0 +1,149 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/fatih/color"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Colors of the token kinds in a Preview
+var (
+	previewKey     = color.New(color.FgCyan)
+	previewString  = color.New(color.FgGreen)
+	previewNumber  = color.New(color.FgYellow)
+	previewLiteral = color.New(color.FgMagenta)
+)
+
+// previewToken is a piece of preview text and its color, nil for punctuation
+type previewToken struct {
+	c    *color.Color
+	text string
+}
+
+// Preview renders v as a colored single-line JSON-like summary of at most
+// width columns, e.g. {id: 7, name: "bob", tags: ["a", …} inside a message;
+// keys that are plain identifiers lose their quotes, zero width means no limit
+func Preview(v any, width int) Value {
+	tokens, err := previewTokens(v)
+	if err != nil {
+		return Value{value: clipColumns(fmt.Sprint(v), width)}
+	}
+	var b strings.Builder
+	used := 0
+	for i, tok := range tokens {
+		w := displayWidth(tok.text)
+		if width <= 0 || used+w < width || used+w == width && i == len(tokens)-1 {
+			b.WriteString(paint(tok.c, tok.text))
+			used += w
+			continue
+		}
+		b.WriteString(paint(tok.c, clipRunes(tok.text, width-used-1)))
+		b.WriteString("…")
+		break
+	}
+	return Value{value: b.String()}
+}
+
+// previewTokens walks the JSON form of v and returns its tokens in order
+// Struct fields keep their declaration order; maps are sorted by key
+func previewTokens(v any) ([]previewToken, error) {
+	ev, err := encodable(v)
+	if err != nil {
+		return nil, err
+	}
+	if ev, err = arrangeKeys(ev); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	type container struct {
+		object bool
+		count  int
+	}
+	var tokens []previewToken
+	var stack []container
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			tokens = append(tokens, previewToken{text: d.String()})
+			continue
+		}
+		key := false
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			switch {
+			case top.object && top.count%2 == 1:
+				tokens = append(tokens, previewToken{text: ": "})
+			case top.count > 0:
+				tokens = append(tokens, previewToken{text: ", "})
+			}
+			key = top.object && top.count%2 == 0
+			top.count++
+		}
+		switch t := tok.(type) {
+		case json.Delim:
+			stack = append(stack, container{object: t == '{'})
+			tokens = append(tokens, previewToken{text: t.String()})
+		case string:
+			if key {
+				tokens = append(tokens, previewToken{previewKey, previewKeyText(t)})
+			} else {
+				tokens = append(tokens, previewToken{previewString, strconv.Quote(t)})
+			}
+		case json.Number:
+			tokens = append(tokens, previewToken{previewNumber, t.String()})
+		case bool:
+			tokens = append(tokens, previewToken{previewLiteral, strconv.FormatBool(t)})
+		case nil:
+			tokens = append(tokens, previewToken{previewLiteral, "null"})
+		}
+	}
+	return tokens, nil
+}
+
+// previewKeyText returns key unquoted when it is a plain identifier
+func previewKeyText(key string) string {
+	for i, r := range key {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return strconv.Quote(key)
+		}
+	}
+	if key == "" {
+		return `""`
+	}
+	return key
+}
+
+// clipColumns shortens s to width columns with an ellipsis, unlimited when width is zero
+func clipColumns(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return truncate(s, width)
+}
+
+// clipRunes returns the longest prefix of s that fits in w columns
+func clipRunes(s string, w int) string {
+	width := 0
+	for i, r := range s {
+		rw := runeWidth(r)
+		if width+rw > w {
+			return s[:i]
+		}
+		width += rw
+	}
+	return s
+}
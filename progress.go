@@ -0,0 +1,221 @@
+package aurora
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Progress bar defaults
+const (
+	progressWidth = 30 // Bar width in columns
+	progressStep  = 10 // Percent between lines on non-terminals
+)
+
+// DefaultProgressTemplate is the bar line used unless WithTemplate is given
+const DefaultProgressTemplate = `{{.Label}} {{.Bar}} {{.Percent}} {{.Current}}/{{.Total}} {{.Rate}} ETA {{.ETA}}`
+
+// defaultProgressTemplate is the parsed DefaultProgressTemplate
+var defaultProgressTemplate = template.Must(template.New("progress").Parse(DefaultProgressTemplate))
+
+// Progress tracks and renders the progress of a single task
+// Terminals show a bar in the live region; other writers get a line every
+// progressStep percent so CI logs stay short. Safe for concurrent use
+type Progress struct {
+	mu        sync.Mutex
+	n         *Notifier
+	label     string
+	total     int64
+	current   int64
+	start     time.Time
+	unit      Unit
+	eta       Estimator
+	tmpl      *template.Template
+	handle    liveLine // Line of the bar in the live region
+	reported  int      // Last percent step written on non-terminals
+	done      bool
+	phases    []*Phase // Weighted parts the progress is composed of
+	active    string   // Name of the most recently updated phase
+	announced int      // Last percent step put on the event stream
+}
+
+// ProgressOption configures a Progress
+type ProgressOption func(*Progress)
+
+// ProgressLine holds the values available to progress templates
+type ProgressLine struct {
+	Label   string // Label given to Progress
+	Bar     string // Rendered bar
+	Percent string // e.g. "42%", empty when the total is unknown
+	Current string // Completed amount in the unit
+	Total   string // Total amount in the unit, "?" when unknown
+	Rate    string // e.g. "12.3 MB/s"
+	ETA     string // Remaining time, "?" when unknown
+	Elapsed string // Time since the Progress started
+}
+
+// WithEstimator sets how the rate behind the ETA is estimated
+// Defaults to EWMA(0.3)
+func WithEstimator(e Estimator) ProgressOption {
+	return func(p *Progress) { p.eta = e }
+}
+
+// WithTemplate sets the text/template used for the bar line
+// Fields are those of ProgressLine; panics on an invalid template
+func WithTemplate(text string) ProgressOption {
+	return func(p *Progress) {
+		p.tmpl = template.Must(template.New("progress").Parse(text))
+	}
+}
+
+// WithUnit sets the unit amounts and rates are shown in
+// Defaults to plain numbers
+func WithUnit(u Unit) ProgressOption {
+	return func(p *Progress) { p.unit = u }
+}
+
+// Progress starts tracking a task of total units
+// Use a total of 0 or less when the size is unknown
+func (n *Notifier) Progress(label string, total int64, opts ...ProgressOption) *Progress {
+	p := &Progress{
+		n:     n,
+		label: label,
+		total: total,
+		start: time.Now(),
+		eta:   EWMA(0.3),
+		tmpl:  defaultProgressTemplate,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.eta.Observe(p.start, 0)
+	return p
+}
+
+// Add advances the progress by delta units
+func (p *Progress) Add(delta int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update(p.current + delta)
+}
+
+// Set moves the progress to current units
+func (p *Progress) Set(current int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update(current)
+}
+
+// Write advances the progress by len(b) so a Progress can sit in io.Copy
+// or an io.TeeReader; it never fails
+func (p *Progress) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Done completes the progress and writes the final line
+// Further updates are ignored
+func (p *Progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	p.done = true
+	if p.total > 0 {
+		p.current = p.total
+	}
+	p.announce(true)
+	p.render(true)
+}
+
+// update records a new position and redraws when due
+// Callers must hold p.mu
+func (p *Progress) update(current int64) {
+	if p.done {
+		return
+	}
+	now := time.Now()
+	p.current = current
+	p.eta.Observe(now, current)
+	p.announce(false)
+
+	p.n.mu.Lock()
+	live := p.n.output.terminal()
+	p.n.mu.Unlock()
+	if live {
+		p.render(false)
+		return
+	}
+	if p.total > 0 {
+		if step := int(p.current*100/p.total) / progressStep * progressStep; step > p.reported && step < 100 {
+			p.reported = step
+			p.render(false)
+		}
+	}
+}
+
+// render writes the current line, in place on terminals
+// final ends the live line and always produces output
+func (p *Progress) render(final bool) {
+	var line strings.Builder
+	p.tmpl.Execute(&line, p.line())
+	text := strings.Join(strings.Fields(line.String()), " ")
+
+	n := p.n
+	n.mu.Lock()
+	live := n.output.terminal()
+	switch {
+	case live && final:
+		n.output.live.remove(&p.handle)
+		n.output.route(ConsoleSink, []byte(n.liveText(InfoLevel, text)+"\n"), nil)
+	case live:
+		n.output.live.set(&p.handle, n.liveText(InfoLevel, text))
+	}
+	n.mu.Unlock()
+	if !live {
+		n.Inlinef(InfoLevel, "%s", text)
+	}
+}
+
+// line computes the template values for the current state
+func (p *Progress) line() ProgressLine {
+	label := p.label
+	if p.active != "" {
+		label += " › " + p.active
+	}
+	l := ProgressLine{
+		Label:   label,
+		Current: p.unit.amount(p.current),
+		Total:   "?",
+		ETA:     "?",
+		Elapsed: time.Since(p.start).Round(time.Second).String(),
+	}
+	rate := p.eta.Rate()
+	l.Rate = p.unit.rate(rate)
+	if p.total <= 0 {
+		return l
+	}
+
+	ratio := min(max(float64(p.current)/float64(p.total), 0), 1)
+	filled := int(ratio * progressWidth)
+	l.Bar = strings.Repeat("█", filled) + strings.Repeat("░", progressWidth-filled)
+	l.Percent = fmt.Sprintf("%d%%", int(ratio*100))
+	l.Total = p.unit.amount(p.total)
+	switch {
+	case p.current >= p.total:
+		l.ETA = "0s"
+	case rate > 0:
+		remaining := time.Duration(float64(p.total-p.current) / rate * float64(time.Second))
+		l.ETA = remaining.Round(time.Second).String()
+	}
+	return l
+}
+
+// NewProgress starts tracking a task using the default Notifier
+// See Notifier.Progress for the rendering rules
+func NewProgress(label string, total int64, opts ...ProgressOption) *Progress {
+	return Default.Progress(label, total, opts...)
+}
@@ -0,0 +1,83 @@
+package aurora
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// progressReader advances a Progress as data is read
+type progressReader struct {
+	r io.Reader
+	p *Progress
+}
+
+// Read reads from the wrapped reader and completes the progress at EOF
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.p.Add(int64(n))
+	if errors.Is(err, io.EOF) {
+		pr.p.Done()
+	}
+	return n, err
+}
+
+// Close completes the progress and closes the wrapped reader if it can be closed
+func (pr *progressReader) Close() error {
+	pr.p.Done()
+	if c, ok := pr.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// ProgressReader wraps r so reading it drives a byte progress bar
+// The total is inferred from r: Len for in-memory readers, otherwise the
+// distance to the end for seekers such as *os.File; unknown sizes still
+// show amounts and rate. The bar completes at EOF or Close
+func (n *Notifier) ProgressReader(label string, r io.Reader, opts ...ProgressOption) io.ReadCloser {
+	opts = append([]ProgressOption{WithUnit(UnitBytes)}, opts...)
+	return &progressReader{r: r, p: n.Progress(label, readerSize(r), opts...)}
+}
+
+// ProgressResponse makes reading resp.Body drive a byte progress bar
+// The total comes from the Content-Length header; resp.Body is replaced
+// in place so callers keep reading and closing it as usual
+func (n *Notifier) ProgressResponse(label string, resp *http.Response, opts ...ProgressOption) {
+	opts = append([]ProgressOption{WithUnit(UnitBytes)}, opts...)
+	resp.Body = &progressReader{r: resp.Body, p: n.Progress(label, resp.ContentLength, opts...)}
+}
+
+// readerSize returns the number of bytes left in r, or -1 when unknown
+func readerSize(r io.Reader) int64 {
+	switch t := r.(type) {
+	case interface{ Len() int }:
+		return int64(t.Len())
+	case io.Seeker:
+		current, err := t.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := t.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := t.Seek(current, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - current
+	}
+	return -1
+}
+
+// ProgressReader wraps r with a byte progress bar using the default Notifier
+// Sizes are inferred from r where possible
+func ProgressReader(label string, r io.Reader, opts ...ProgressOption) io.ReadCloser {
+	return Default.ProgressReader(label, r, opts...)
+}
+
+// ProgressResponse adds a byte progress bar to resp.Body using the default Notifier
+// One call for download progress
+func ProgressResponse(label string, resp *http.Response, opts ...ProgressOption) {
+	Default.ProgressResponse(label, resp, opts...)
+}
@@ -0,0 +1,30 @@
+package aurora
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// stdin supplies answers to Notifiers created without WithInput
+// Shared so buffered input is not lost between prompts
+var stdin = bufio.NewReader(os.Stdin)
+
+// prompt writes question to the console and reads a single line of answer
+// The answer is returned without its line ending; a final unterminated line counts
+func (n *Notifier) prompt(question string) (string, error) {
+	n.mu.Lock()
+	n.output.route(ConsoleSink, []byte(n.colorMode.apply(question)), nil)
+	r := n.input
+	n.mu.Unlock()
+	if r == nil {
+		r = stdin
+	}
+
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package aurora
+
+// raceEnabled reports whether tests run under the race detector,
+// which adds allocations that would trip the allocation guards
+const raceEnabled = true
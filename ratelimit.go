@@ -0,0 +1,62 @@
+package aurora
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// rateLimit caps console output per second so runaway loops stay readable
+// Output over the cap is dropped and reported once the next second starts
+// Guarded by the Notifier mutex
+type rateLimit struct {
+	lines   int       // Lines allowed per second, unlimited when zero
+	bytes   int       // Bytes allowed per second, unlimited when zero
+	window  time.Time // Start of the current second
+	used    [2]int    // Lines and bytes written in the current second
+	dropped int       // Lines dropped since the last notice
+}
+
+// allow reports whether p fits in the current second and counts it
+// A pending drop notice is returned once a new second has started
+func (r *rateLimit) allow(p []byte, now time.Time) (ok bool, notice string) {
+	if now.Sub(r.window) >= time.Second {
+		r.window, r.used = now, [2]int{}
+		notice = r.notice()
+	}
+	lines := max(bytes.Count(p, []byte{'\n'}), 1)
+	if (r.lines > 0 && r.used[0]+lines > r.lines) || (r.bytes > 0 && r.used[1]+len(p) > r.bytes) {
+		r.dropped += lines
+		return false, notice
+	}
+	r.used[0] += lines
+	r.used[1] += len(p)
+	return true, notice
+}
+
+// notice returns the drop report and resets the count, "" when nothing was dropped
+func (r *rateLimit) notice() string {
+	if r.dropped == 0 {
+		return ""
+	}
+	msg := fmt.Sprintf(tr("… %d %s dropped by the output rate limit")+"\n", r.dropped, plural(r.dropped, "line", "lines"))
+	r.dropped = 0
+	return msg
+}
+
+// SetRateLimit caps console output at lines and bytes per second
+// Excess output is dropped and a notice tells how much; zero means
+// unlimited and SetRateLimit(0, 0) turns the guard off. Sinks are not limited
+func (n *Notifier) SetRateLimit(lines, bytes int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if lines <= 0 && bytes <= 0 {
+		n.output.limit = nil
+		return
+	}
+	n.output.limit = &rateLimit{lines: max(lines, 0), bytes: max(bytes, 0)}
+}
+
+// SetRateLimit caps console output of the default Notifier per second
+// Keeps a runaway debug loop from flooding the terminal
+func SetRateLimit(lines, bytes int) { Default.SetRateLimit(lines, bytes) }
@@ -0,0 +1,143 @@
+package aurora
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// frameRate is the number of live redraws per second, set with SetFrameRate
+// Guarded by the package mutex
+var frameRate = 12
+
+// liveLine is one line of the live region owned by a spinner, bar or status
+type liveLine struct {
+	text string
+}
+
+// renderer owns the live region at the bottom of a terminal
+// Live lines are redrawn together at most frameRate times per second, so
+// many fast updating bars cost a few writes per frame. Permanent output
+// is written above the region, which is redrawn right after it
+// Guarded by the Notifier mutex mu
+type renderer struct {
+	mu       *sync.Mutex   // Notifier mutex, taken by the frame loop
+	s        *switchWriter // Console the region is drawn on
+	lines    []*liveLine   // Live lines, top to bottom
+	fleeting *liveLine     // Ephemeral line removed by the next permanent write
+	drawn    int           // Lines of the region currently on screen
+	dirty    bool          // Whether the region changed since the last frame
+	running  bool          // Whether the frame loop is active
+}
+
+// SetFrameRate sets how many times per second live output is redrawn
+// Lower rates help slow links such as SSH sessions; default is 12
+func SetFrameRate(fps int) {
+	mu.Lock()
+	defer mu.Unlock()
+	frameRate = max(fps, 1)
+}
+
+// set shows text on line l, adding l to the bottom of the region if needed
+func (r *renderer) set(l *liveLine, text string) {
+	l.text = text
+	if !slices.Contains(r.lines, l) {
+		r.lines = append(r.lines, l)
+	}
+	r.dirty = true
+	r.start()
+}
+
+// remove takes l out of the region on the next frame or permanent write
+func (r *renderer) remove(l *liveLine) {
+	if i := slices.Index(r.lines, l); i >= 0 {
+		r.lines = slices.Delete(r.lines, i, i+1)
+		r.dirty = true
+		r.start()
+	}
+}
+
+// before erases the region so permanent output lands in its place
+// Any Ephemeral line is dropped for good
+func (r *renderer) before() {
+	if r.fleeting != nil {
+		r.remove(r.fleeting)
+		r.fleeting = nil
+	}
+	r.erase()
+}
+
+// after redraws the region below permanent output
+func (r *renderer) after() {
+	if len(r.lines) > 0 {
+		r.draw()
+	}
+}
+
+// erase clears the drawn region and leaves the cursor where it began
+func (r *renderer) erase() {
+	if r.drawn == 0 {
+		return
+	}
+	fmt.Fprintf(r.s.console(), "\r\x1b[%dA\x1b[J", r.drawn)
+	r.drawn = 0
+	r.dirty = len(r.lines) > 0
+}
+
+// draw replaces the region with the current lines
+// Lines are cut to the terminal width so cursor movement stays exact
+func (r *renderer) draw() {
+	r.erase()
+	width := terminalWidth() - 1
+	var b strings.Builder
+	for _, l := range r.lines {
+		text := l.text
+		if displayWidth(text) > width {
+			text = truncate(StripANSI(text), width)
+		}
+		b.WriteString(text + "\n")
+	}
+	r.s.console().Write([]byte(b.String()))
+	r.drawn = len(r.lines)
+	r.dirty = false
+}
+
+// start runs the frame loop unless it is already active
+func (r *renderer) start() {
+	if r.running {
+		return
+	}
+	r.running = true
+	go r.loop()
+}
+
+// loop redraws the region once per frame while it changes
+// Exits once the region is empty and up to date
+func (r *renderer) loop() {
+	mu.RLock()
+	interval := time.Second / time.Duration(frameRate)
+	mu.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		r.mu.Lock()
+		if r.dirty && r.s.held == nil {
+			r.draw()
+		}
+		if len(r.lines) == 0 && !r.dirty {
+			r.running = false
+			r.mu.Unlock()
+			return
+		}
+		r.mu.Unlock()
+	}
+}
+
+// liveText styles text like a level entry for the live region
+// Callers must hold the mutex
+func (n *Notifier) liveText(level LogLevel, text string) string {
+	return n.colorMode.apply(paint(n.color(level), n.formatWithPrefix(text)))
+}
@@ -0,0 +1,74 @@
+package aurora
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IDGenerator creates request IDs for WithRequestID
+type IDGenerator func() string
+
+// crockford is the base32 alphabet of ULIDs, without I, L, O and U
+var crockford = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// ShortID returns 8 random lowercase base32 characters, the default generator
+// Short enough to read aloud, unique enough to correlate one process's requests
+func ShortID() string {
+	var b [5]byte
+	rand.Read(b[:])
+	return strings.ToLower(crockford.EncodeToString(b[:]))
+}
+
+// UUID returns a random version 4 UUID, e.g. "0b4f5c1e-8d2a-4f6b-9c3e-5a7d1e2f3a4b"
+func UUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// ULID returns a 26 character ID starting with the current time
+// IDs created later sort after earlier ones
+func ULID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	rand.Read(b[6:])
+	// 128 bits need 26 characters; the leading zero bits keep the time first
+	var padded [20]byte
+	copy(padded[4:], b[:])
+	return crockford.EncodeToString(padded[:])[6:]
+}
+
+// WithIDGenerator sets how WithRequestID creates IDs, ShortID when nil
+// e.g. WithIDGenerator(ULID) for IDs that sort by time
+func WithIDGenerator(gen IDGenerator) Option {
+	return func(n *Notifier) { n.idGen = gen }
+}
+
+// WithRequestID returns a derived Notifier stamping every entry with a new ID
+// The ID is shown dimmed as request_id and is a field in JSON output;
+// pass RequestID on, e.g. in a response header, to correlate both sides
+func (n *Notifier) WithRequestID() *Notifier {
+	gen := n.idGen
+	if gen == nil {
+		gen = ShortID
+	}
+	id := gen()
+	child := n.WithFields(Fields{"request_id": id})
+	child.reqID = id
+	return child
+}
+
+// RequestID returns the ID set by WithRequestID, empty if none
+func (n *Notifier) RequestID() string {
+	return n.reqID
+}
+
+// WithRequestID derives a Notifier with a new request ID from the default one
+// Typically called once per incoming request
+func WithRequestID() *Notifier { return Default.WithRequestID() }
@@ -0,0 +1,61 @@
+package aurora
+
+import (
+	"fmt"
+	"time"
+)
+
+// Retry calls fn up to attempts times, reporting every failed attempt
+// The wait between attempts starts at backoff and doubles each time
+// Logs a final Success or Failure and returns the last error from fn
+func Retry(n *Notifier, attempts int, backoff time.Duration, fn func() error) error {
+	if n == nil {
+		n = Default
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	wait := backoff
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			n.Success(tr("succeeded on attempt %d/%d"), attempt, attempts)
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+		n.countdown(WarnLevel, wait, tr("attempt %d/%d failed: %v, retrying in"), attempt, attempts, err)
+		wait *= 2
+	}
+	n.Failure(tr("failed after %d attempts: %v"), attempts, err)
+	return err
+}
+
+// countdown prints a message followed by the remaining wait time and blocks for d
+// On a terminal the remaining time is refreshed in place every second
+// Other writers receive a single line so logs stay readable
+func (n *Notifier) countdown(level LogLevel, d time.Duration, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	n.mu.Lock()
+	terminal := n.output.terminal()
+	n.mu.Unlock()
+	if !terminal {
+		n.Inlinef(level, "%s %s", msg, d)
+		time.Sleep(d)
+		return
+	}
+
+	var handle liveLine
+	for remaining := d; remaining > 0; remaining -= time.Second {
+		n.mu.Lock()
+		line := fmt.Sprintf("%s %s %s", n.symbol(level), n.formatWithPrefix(msg), remaining)
+		n.output.live.set(&handle, n.colorMode.apply(paint(n.color(level), line)))
+		n.mu.Unlock()
+		time.Sleep(min(remaining, time.Second))
+	}
+	n.mu.Lock()
+	n.output.live.remove(&handle)
+	n.mu.Unlock()
+}
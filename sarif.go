@@ -0,0 +1,103 @@
+package aurora
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+// SARIF 2.1.0 documents as read by code scanning UIs
+// Only the parts WriteSARIF fills in are modeled
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules,omitempty"`
+	}
+	sarifRule struct {
+		ID string `json:"id"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId,omitempty"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations,omitempty"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysical `json:"physicalLocation"`
+	}
+	sarifPhysical struct {
+		ArtifactLocation sarifArtifact `json:"artifactLocation"`
+		Region           *sarifRegion  `json:"region,omitempty"`
+	}
+	sarifArtifact struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+// sarifLevel maps a severity onto the SARIF result levels
+func sarifLevel(level LogLevel) string {
+	switch {
+	case level >= ErrorLevel:
+		return "error"
+	case level == WarnLevel:
+		return "warning"
+	}
+	return "note"
+}
+
+// WriteSARIF writes the findings as a SARIF 2.1.0 log produced by tool
+// The same data printed by Notifier.Diagnostics, ready for code scanning
+// uploads; notes and suggestions are appended to the message text
+func (b *DiagnosticBag) WriteSARIF(w io.Writer, tool string) error {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: tool}}, Results: []sarifResult{}}
+	seen := make(map[string]bool)
+	for _, d := range b.Diagnostics() {
+		if d.Rule != "" && !seen[d.Rule] {
+			seen[d.Rule] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: d.Rule})
+		}
+		text := d.Message
+		for _, note := range d.Notes {
+			text += "\n" + note
+		}
+		for _, fix := range d.Suggestions {
+			text += "\n" + fix
+		}
+		result := sarifResult{RuleID: d.Rule, Level: sarifLevel(d.Severity), Message: sarifMessage{Text: text}}
+		if d.File != "" {
+			loc := sarifLocation{PhysicalLocation: sarifPhysical{ArtifactLocation: sarifArtifact{URI: filepath.ToSlash(d.File)}}}
+			if d.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Col}
+			}
+			result.Locations = []sarifLocation{loc}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", IndentSpace2)
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
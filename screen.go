@@ -0,0 +1,124 @@
+package aurora
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Terminal control sequences used by Screen
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l" // Switch to the alternate buffer, hide the cursor
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l" // Show the cursor, restore the main buffer
+	homeAndClear   = "\x1b[H\x1b[2J"        // Move home and erase the screen
+)
+
+// sparkBars are the block characters used by Sparkline, lowest first
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Screen is a full screen dashboard on the terminal's alternate buffer
+// Each Draw replaces the whole screen, so top-like views simply redraw
+// on a timer. Non-terminals receive every frame as a normal block
+type Screen struct {
+	mu     sync.Mutex
+	n      *Notifier
+	live   bool
+	closed bool
+}
+
+// Screen switches the terminal to the alternate screen buffer
+// The previous contents return on Close; call it with defer
+func (n *Notifier) Screen() *Screen {
+	s := &Screen{n: n}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if s.live = n.output.terminal(); s.live {
+		n.output.route(ConsoleSink, []byte(enterAltScreen), nil)
+	}
+	return s
+}
+
+// Draw replaces the screen with blocks, one after another
+// Build blocks with Table, Sparkline and Status or any rendered text
+func (s *Screen) Draw(blocks ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	var frame strings.Builder
+	if s.live {
+		frame.WriteString(homeAndClear)
+	}
+	for _, block := range blocks {
+		frame.WriteString(strings.TrimSuffix(block, "\n"))
+		frame.WriteString("\n")
+	}
+
+	n := s.n
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.route(ConsoleSink, []byte(n.colorMode.apply(frame.String())), nil)
+}
+
+// Status renders a single status line in the style of level
+func (s *Screen) Status(level LogLevel, format string, args ...any) string {
+	n := s.n
+	msg := n.formatWithPrefix(fmt.Sprintf(format, args...))
+	if symbol := n.symbol(level); symbol != "" {
+		msg = symbol + " " + msg
+	}
+	return paint(n.color(level), msg)
+}
+
+// Table renders header and rows as a table fitting the terminal width
+func (s *Screen) Table(header []string, rows [][]string) string {
+	return renderTable(header, rows, terminalWidth(), nil)
+}
+
+// Close restores the main screen and its previous contents
+// Further Draw calls are ignored
+func (s *Screen) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.live {
+		s.n.mu.Lock()
+		defer s.n.mu.Unlock()
+		s.n.output.route(ConsoleSink, []byte(leaveAltScreen), nil)
+	}
+	return nil
+}
+
+// Sparkline renders values as a compact bar chart such as "▁▃▅█"
+// Finite values are scaled between their minimum and maximum; ±Inf becomes
+// the highest or lowest bar and NaN a space
+func Sparkline(values []float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+			b.WriteRune(' ')
+		case math.IsInf(v, 1):
+			b.WriteRune(sparkBars[len(sparkBars)-1])
+		case math.IsInf(v, -1):
+			b.WriteRune(sparkBars[0])
+		case hi == lo:
+			b.WriteRune(sparkBars[len(sparkBars)/2])
+		default:
+			i := int((v - lo) / (hi - lo) * float64(len(sparkBars)-1))
+			b.WriteRune(sparkBars[i])
+		}
+	}
+	return b.String()
+}
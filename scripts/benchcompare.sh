@@ -0,0 +1,19 @@
+#!/bin/sh
+# Runs the write path benchmarks and compares them with the committed baseline
+# Usage: scripts/benchcompare.sh [count]   (needs golang.org/x/perf/cmd/benchstat)
+# Refresh the baseline after an intended change with:
+#   go test -run '^$' -bench . -benchmem -count 3 . > testdata/bench_baseline.txt
+set -e
+cd "$(dirname "$0")/.."
+
+count=${1:-5}
+current=$(mktemp)
+trap 'rm -f "$current"' EXIT
+
+go test -run '^$' -bench . -benchmem -count "$count" . | tee "$current"
+if command -v benchstat >/dev/null 2>&1; then
+	benchstat testdata/bench_baseline.txt "$current"
+else
+	echo "benchstat not found: go install golang.org/x/perf/cmd/benchstat@latest" >&2
+	exit 1
+fi
@@ -0,0 +1,33 @@
+package aurora
+
+import (
+	"slices"
+	"time"
+)
+
+// OnStart runs fn once New has applied every option
+// Typically prints a banner that opens the run
+func OnStart(fn func(*Notifier)) Option {
+	return func(n *Notifier) {
+		n.startHooks = append(slices.Clip(n.startHooks), fn)
+	}
+}
+
+// OnExit runs fn from Close with the final statistics
+// Typically prints a footer with the duration and warning/error counts
+func OnExit(fn func(*Notifier, Stats)) Option {
+	return func(n *Notifier) {
+		n.exitHooks = append(slices.Clip(n.exitHooks), fn)
+	}
+}
+
+// Summary is an OnExit hook printing the duration and problem counts
+// e.g. "finished in 3.2s: 2 warnings, 1 error"
+func Summary(n *Notifier, s Stats) {
+	elapsed := s.Elapsed.Round(100 * time.Millisecond)
+	if s.Errors() > 0 {
+		n.Failure(tr("finished in %s: %s"), elapsed, s)
+		return
+	}
+	n.Success(tr("finished in %s: %s"), elapsed, s)
+}
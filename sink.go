@@ -0,0 +1,138 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"io"
+	"slices"
+)
+
+// SinkTag identifies a class of destination for routing entries
+// Tags can be combined, e.g. ConsoleSink|FileSink
+type SinkTag uint8
+
+const (
+	ConsoleSink SinkTag = 1 << iota // The writer given to New or SetOutput
+	FileSink                        // Log files and other persistent writers added with AddSink
+
+	AllSinks = ConsoleSink | FileSink // Every destination, the default for entries
+)
+
+// sink is an additional destination of a Notifier family
+type sink struct {
+	tag   SinkTag
+	w     io.Writer
+	plain bool // Whether entries are written without color and symbols
+}
+
+// entrySink receives entries as structured values instead of text
+type entrySink struct {
+	tag SinkTag
+	fn  func(Entry)
+}
+
+// Event builds a single entry with routing hints
+// Created by At; nothing is written until Msg is called
+type Event struct {
+	n     *Notifier
+	level LogLevel
+	sinks SinkTag
+	style Style
+}
+
+// AddSink sends output of the Notifier and those derived from it to w as well
+// The tag decides which entries reach w when Event.Only is used
+func (n *Notifier) AddSink(tag SinkTag, w io.Writer) {
+	if w == nil {
+		misuse("AddSink with a nil writer")
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.sinks = append(slices.Clip(n.output.sinks), sink{tag: tag, w: w})
+}
+
+// AddEntrySink hands every entry of the Notifier family to fn before formatting
+// Lets sinks store or forward fields without parsing the rendered text;
+// fn runs under the Notifier lock and must not log through the same family
+func (n *Notifier) AddEntrySink(tag SinkTag, fn func(Entry)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.entrySinks = append(slices.Clip(n.output.entrySinks), entrySink{tag: tag, fn: fn})
+}
+
+// deliver passes e to the entry sinks matching its destinations
+// Internal helper; callers must hold the mutex
+func (s *switchWriter) deliver(e entry) {
+	if s.events != nil {
+		s.event("log", e.ToMap())
+	}
+	for _, sk := range s.entrySinks {
+		if sk.tag&e.destinations() != 0 {
+			sk.fn(e.Entry)
+		}
+	}
+}
+
+// MirrorPlain sends a plain text copy of every entry to w
+// Colors are stripped and symbols replaced by level names like "[WARN]",
+// which keeps log files readable; w is tagged as a FileSink
+func (n *Notifier) MirrorPlain(w io.Writer) {
+	if w == nil {
+		misuse("MirrorPlain with a nil writer")
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.sinks = append(slices.Clip(n.output.sinks), sink{tag: FileSink, w: w, plain: true})
+}
+
+// At starts an entry at level that can be routed before it is written
+// e.g. n.At(InfoLevel).Only(ConsoleSink).Msg("ready")
+func (n *Notifier) At(level LogLevel) *Event {
+	return &Event{n: n, level: level}
+}
+
+// Only restricts the entry to destinations matching tags
+// Keeps decorative output such as banners out of file sinks
+func (ev *Event) Only(tags SinkTag) *Event {
+	ev.sinks = tags
+	return ev
+}
+
+// Style paints the entry with style instead of the level color
+// e.g. n.At(InfoLevel).Style(NewStyle(color.FgHiBlue)).Msg("deployed")
+// keeps the symbol, prefix and fields of a regular Info entry
+func (ev *Event) Style(style Style) *Event {
+	ev.style = style
+	return ev
+}
+
+// Msg formats and writes the entry like Inlinef
+func (ev *Event) Msg(format string, args ...any) {
+	n := ev.n
+	if !n.enabled(ev.level) {
+		return
+	}
+	e := n.newEntry(ev.level, fmt.Sprintf(format, args...))
+	e.sinks = ev.sinks
+	if len(ev.style) > 0 {
+		e.color = color.New(ev.style...)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.emit(e)
+}
+
+// AddSink adds a destination to the default Notifier
+// Mirrors console output into files or remote writers
+func AddSink(tag SinkTag, w io.Writer) { Default.AddSink(tag, w) }
+
+// MirrorPlain sends a plain text copy of the default Notifier's entries to w
+// Simplest way to keep a readable log file next to the console
+func MirrorPlain(w io.Writer) { Default.MirrorPlain(w) }
+
+// At starts a routable entry using the default Notifier
+// Fine-grained control over where a message is written
+func At(level LogLevel) *Event { return Default.At(level) }
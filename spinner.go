@@ -0,0 +1,161 @@
+package aurora
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SpinnerStyle is a named spinner animation
+type SpinnerStyle struct {
+	Frames   []string      // Frames shown in turn
+	Interval time.Duration // Time each frame is shown
+}
+
+// TaskIcons are the icons a spinner finishes with
+type TaskIcons struct {
+	Success string
+	Failure string
+}
+
+// Built-in spinner styles, extended with RegisterSpinner and guarded by mu
+var spinnerStyles = map[string]SpinnerStyle{
+	"dots": {Frames: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}, Interval: 80 * time.Millisecond},
+	"line": {Frames: []string{"-", "\\", "|", "/"}, Interval: 130 * time.Millisecond},
+	"arc":  {Frames: []string{"◜", "◠", "◝", "◞", "◡", "◟"}, Interval: 100 * time.Millisecond},
+}
+
+// RegisterSpinner adds or replaces a named spinner style
+// Select it with Theme.Spinner or Notifier.Spinner options
+func RegisterSpinner(name string, style SpinnerStyle) {
+	if len(style.Frames) == 0 {
+		return
+	}
+	if style.Interval <= 0 {
+		style.Interval = 100 * time.Millisecond
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	spinnerStyles[name] = style
+}
+
+// spinnerStyle returns the style named name, falling back to "dots"
+func spinnerStyle(name string) SpinnerStyle {
+	mu.RLock()
+	defer mu.RUnlock()
+	if style, ok := spinnerStyles[name]; ok {
+		return style
+	}
+	return spinnerStyles["dots"]
+}
+
+// Spinner animates while a task of unknown length runs
+// Terminals show the animation in place; other writers get the label
+// once and the result line. Finish it with Success, Fail or Stop
+type Spinner struct {
+	n      *Notifier
+	label  string
+	style  SpinnerStyle
+	icons  TaskIcons
+	stop   chan struct{}
+	done   sync.WaitGroup
+	once   sync.Once
+	live   bool
+	handle liveLine  // Line of the spinner in the live region
+	start  time.Time // Start of the task, for the recorded duration
+}
+
+// Spinner starts a spinner showing label with the theme's style and icons
+func (n *Notifier) Spinner(label string) *Spinner {
+	s := &Spinner{
+		n:     n,
+		label: label,
+		style: spinnerStyle(n.spinner),
+		icons: TaskIcons{Success: IconSuccess, Failure: IconError},
+		stop:  make(chan struct{}),
+		start: time.Now(),
+	}
+	if n.icons.Success != "" {
+		s.icons.Success = n.icons.Success
+	}
+	if n.icons.Failure != "" {
+		s.icons.Failure = n.icons.Failure
+	}
+
+	n.started(label)
+	n.mu.Lock()
+	s.live = n.output.terminal()
+	n.mu.Unlock()
+	if !s.live {
+		n.Inlinef(InfoLevel, "%s…", label)
+		return s
+	}
+	s.done.Add(1)
+	go s.animate()
+	return s
+}
+
+// animate draws frames until the spinner is stopped
+func (s *Spinner) animate() {
+	defer s.done.Done()
+	ticker := time.NewTicker(s.style.Interval)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		s.draw(s.style.Frames[frame%len(s.style.Frames)] + " " + s.label)
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// draw shows text on the spinner's live line
+func (s *Spinner) draw(text string) {
+	n := s.n
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.output.live.set(&s.handle, n.liveText(InfoLevel, text))
+}
+
+// Fail stops the spinner with the failure icon and message at Error level
+// The message is the failure recorded for WriteJUnit
+func (s *Spinner) Fail(format string, args ...any) {
+	s.finish(ErrorLevel, s.icons.Failure, fmt.Sprintf(format, args...))
+}
+
+// Stop stops the spinner and clears its line without a result
+func (s *Spinner) Stop() {
+	s.finish(NoLevel, "", "")
+}
+
+// Success stops the spinner with the success icon and message at Info level
+func (s *Spinner) Success(format string, args ...any) {
+	s.finish(InfoLevel, s.icons.Success, fmt.Sprintf(format, args...))
+}
+
+// finish stops the animation once and writes the result line
+func (s *Spinner) finish(level LogLevel, icon, msg string) {
+	s.once.Do(func() {
+		if s.live {
+			close(s.stop)
+			s.done.Wait()
+			s.n.mu.Lock()
+			s.n.output.live.remove(&s.handle)
+			s.n.mu.Unlock()
+		}
+		r := stepResult{name: s.label, start: s.start, took: time.Since(s.start), skipped: level == NoLevel}
+		if level == ErrorLevel {
+			r.err = errors.New(msg)
+		}
+		s.n.record(r)
+		if msg != "" {
+			s.n.Inlinef(level, "%s %s", icon, msg)
+		}
+	})
+}
+
+// Spinner starts a spinner using the default Notifier
+// See Notifier.Spinner
+func NewSpinner(label string) *Spinner { return Default.Spinner(label) }
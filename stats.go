@@ -0,0 +1,134 @@
+package aurora
+
+import (
+	"fmt"
+	"maps"
+	"time"
+)
+
+// defaultExitCodes gives make-style exit statuses: 1 after warnings, 2 after errors
+var defaultExitCodes = map[LogLevel]int{
+	WarnLevel:  1,
+	ErrorLevel: 2,
+}
+
+// Stats is a snapshot of what a Notifier family has logged
+// Counts include entries from Notifiers derived with With or WithOptions
+type Stats struct {
+	Counts       map[LogLevel]int   // Entries written per level
+	Bytes        int64              // Bytes written to the console output
+	Dropped      int                // Entries removed by Suppress, Only or middleware
+	Stalled      int                // Console writes dropped while the output was blocked
+	LastError    time.Time          // Time of the last Error entry, zero if none
+	LastCritical time.Time          // Time of the last Critical entry, zero if none
+	Elapsed      time.Duration      // Time since the Notifier was created or stats were reset
+	Metrics      map[string]float64 // Counter and gauge values by name, nil if none
+}
+
+// statsCounter accumulates Stats for a Notifier family
+// Guarded by the Notifier mutex
+type statsCounter struct {
+	counts       map[LogLevel]int
+	start        time.Time
+	dropped      int
+	lastError    time.Time
+	lastCritical time.Time
+}
+
+// newStatsCounter creates an empty statsCounter
+func newStatsCounter() *statsCounter {
+	return &statsCounter{counts: make(map[LogLevel]int), start: time.Now()}
+}
+
+// record counts a written entry
+func (s *statsCounter) record(e entry) {
+	s.counts[e.Level]++
+	switch e.Level {
+	case ErrorLevel:
+		s.lastError = e.Time
+	case CriticalLevel:
+		s.lastCritical = e.Time
+	}
+}
+
+// Errors returns the number of entries at Error level or above
+// Custom levels between the predefined ones count too; NoLevel does not
+func (s Stats) Errors() int {
+	total := 0
+	for level, count := range s.Counts {
+		if level >= ErrorLevel && level != NoLevel {
+			total += count
+		}
+	}
+	return total
+}
+
+// Warnings returns the number of entries at Warn level
+func (s Stats) Warnings() int {
+	return s.Counts[WarnLevel]
+}
+
+// String summarizes warnings and errors, e.g. "2 warnings, 1 error"
+func (s Stats) String() string {
+	warnings, errors := s.Warnings(), s.Errors()
+	return fmt.Sprintf("%d %s, %d %s",
+		warnings, plural(warnings, "warning", "warnings"),
+		errors, plural(errors, "error", "errors"))
+}
+
+// ExitCode returns a process exit status based on what was logged
+// Each configured level maps to a code used once an entry at or above it
+// was written; the most severe match wins and 0 means nothing matched
+// Defaults to 1 after warnings and 2 after errors, see WithExitCodes
+func (n *Notifier) ExitCode() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	codes := n.exitCodes
+	if codes == nil {
+		codes = defaultExitCodes
+	}
+	code, matched, found := 0, LogLevel(0), false
+	for threshold, c := range codes {
+		if found && threshold <= matched {
+			continue
+		}
+		for level, count := range n.stats.counts {
+			if count > 0 && level != NoLevel && level >= threshold {
+				code, matched, found = c, threshold, true
+				break
+			}
+		}
+	}
+	return code
+}
+
+// ResetStats clears the counters reported by Stats
+// Does not affect the suppressed line count reported by Close
+func (n *Notifier) ResetStats() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	*n.stats = *newStatsCounter()
+	n.output.bytes = 0
+}
+
+// Stats returns a snapshot of the entries logged so far
+// Handy for end of run summaries and assertions in tests
+func (n *Notifier) Stats() Stats {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return Stats{
+		Counts:       maps.Clone(n.stats.counts),
+		Bytes:        n.output.bytes,
+		Dropped:      n.stats.dropped,
+		Stalled:      n.output.stalled(),
+		LastError:    n.stats.lastError,
+		LastCritical: n.stats.lastCritical,
+		Elapsed:      time.Since(n.stats.start),
+		Metrics:      n.metrics.values(),
+	}
+}
+
+// ExitCode returns an exit status for the default Notifier
+// Use as os.Exit(aurora.ExitCode()) for make-style semantics
+func ExitCode() int { return Default.ExitCode() }
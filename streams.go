@@ -0,0 +1,82 @@
+package aurora
+
+import (
+	"io"
+	"math"
+	"os"
+)
+
+// Stream is a standard stream console output can be routed to
+type Stream uint8
+
+const (
+	Stdout Stream = iota // The Notifier's own writer, os.Stdout by default
+	Stderr               // The error writer of the StreamPolicy
+)
+
+// StreamPolicy decides which stream the console output of each level goes to
+// Entries at Stderr or above go to stderr, lower levels to the Notifier's
+// writer; NoLevel lines such as Printf(NoLevel, ...) follow Plain
+type StreamPolicy struct {
+	Stderr LogLevel  // Lowest level written to stderr
+	Plain  Stream    // Stream of NoLevel output
+	Errors io.Writer // Writer used as stderr, os.Stderr when nil
+}
+
+// Predefined stream policies for SetStreams
+var (
+	// SplitStreams sends warnings and worse to stderr, everything else to stdout
+	SplitStreams = StreamPolicy{Stderr: WarnLevel, Plain: Stdout}
+
+	// POSIXStreams keeps stdout for data: every leveled entry, text or JSON,
+	// goes to stderr and only NoLevel output reaches stdout
+	POSIXStreams = StreamPolicy{Stderr: math.MinInt, Plain: Stdout}
+)
+
+// Stream returns the stream entries at level are written to
+func (p StreamPolicy) Stream(level LogLevel) Stream {
+	switch {
+	case level == NoLevel:
+		return p.Plain
+	case level >= p.Stderr:
+		return Stderr
+	}
+	return Stdout
+}
+
+// errors returns the writer used as stderr
+func (p StreamPolicy) errors() io.Writer {
+	if p.Errors != nil {
+		return p.Errors
+	}
+	return os.Stderr
+}
+
+// SetStreams routes console entries of the Notifier family by level
+// e.g. SetStreams(&POSIXStreams) keeps stdout pipeline-clean for a CLI;
+// nil sends everything to the Notifier's writer again. Sinks are unaffected
+func (n *Notifier) SetStreams(policy *StreamPolicy) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if policy != nil {
+		copied := *policy
+		policy = &copied
+	}
+	n.output.streams = policy
+}
+
+// routeLevel sends p like route, writing the console part to the
+// stream the policy picks for level
+// Internal helper; callers must hold the mutex
+func (s *switchWriter) routeLevel(level LogLevel, tags SinkTag, p, plain []byte) (int, error) {
+	if s.streams == nil || s.streams.Stream(level) == Stdout {
+		return s.route(tags, p, plain)
+	}
+	s.target = s.streams.errors()
+	defer func() { s.target = nil }()
+	return s.route(tags, p, plain)
+}
+
+// SetStreams routes console entries of the default Notifier by level
+// Decides what a CLI prints to stdout and stderr
+func SetStreams(policy *StreamPolicy) { Default.SetStreams(policy) }
@@ -0,0 +1,64 @@
+package aurora
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxMisuses bounds the reports kept for Check between calls
+const maxMisuses = 100
+
+// Misuse detection state, guarded by the package mutex
+var (
+	strict  bool     // Whether misuse panics instead of being recorded
+	misuses []string // Reports collected for Check, oldest first
+)
+
+// SetStrict makes misuse panic instead of only being recorded for Check
+// Catches format verbs without matching arguments, logging after Close and
+// nil sink writers; meant for development builds and tests
+func SetStrict(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	strict = enabled
+}
+
+// Check returns the misuse recorded since the last call, nil if none
+// Run it at the end of a test to fail on silent formatting bugs; reports
+// are collected for every Notifier, so parallel tests see each other's
+func Check() error {
+	mu.Lock()
+	found := misuses
+	misuses = nil
+	mu.Unlock()
+
+	errs := make([]error, len(found))
+	for i, problem := range found {
+		errs[i] = errors.New("aurora: " + problem)
+	}
+	return errors.Join(errs...)
+}
+
+// misuse reports a problem with how aurora is called
+// Panics in strict mode; otherwise records it for Check
+func misuse(format string, args ...any) {
+	problem := fmt.Sprintf(format, args...)
+	mu.Lock()
+	panics := strict
+	if !panics && len(misuses) < maxMisuses {
+		misuses = append(misuses, problem)
+	}
+	mu.Unlock()
+	if panics {
+		panic("aurora: " + problem)
+	}
+}
+
+// vetFormat reports msg as misuse when fmt flagged a verb/argument mismatch
+// fmt marks those in place, e.g. "%!d(MISSING)" or "%!(EXTRA int=1)"
+func vetFormat(format, msg string) {
+	if strings.Contains(msg, "%!") && !strings.Contains(format, "%!") {
+		misuse("format %q: verbs and arguments do not match: %q", format, msg)
+	}
+}
@@ -0,0 +1,47 @@
+package aurora
+
+import "strings"
+
+// SymbolPosition decides where the level symbol appears in an entry
+type SymbolPosition int
+
+const (
+	SymbolFirst     SymbolPosition = iota // Symbol starts the line, the default
+	SymbolAfterTime                       // Symbol follows the timestamp of Logf entries
+	SymbolHidden                          // No symbol at all
+)
+
+// WithSymbolPosition moves the level symbol after the timestamp or hides it
+// e.g. WithSymbolPosition(SymbolAfterTime) for "2025-03-25 01:23:45 PM [✔] ready"
+func WithSymbolPosition(pos SymbolPosition) Option {
+	return func(n *Notifier) { n.symbolPos = pos }
+}
+
+// WithSymbolWidth pads symbols to width terminal columns
+// Keeps messages aligned when custom symbols mix narrow and wide glyphs
+func WithSymbolWidth(width int) Option {
+	return func(n *Notifier) { n.symbolWidth = width }
+}
+
+// head renders the symbol and timestamp that start the lead of e
+// Empty when narrow and the entry is not stamped
+// Internal helper; callers must hold the mutex
+func (n *Notifier) head(e entry, narrow bool) string {
+	var symbol, stamp string
+	if !narrow && n.symbolPos != SymbolHidden {
+		symbol = n.symbol(e.Level)
+		if n.symbolWidth > 0 {
+			symbol += strings.Repeat(" ", max(0, n.symbolWidth-displayWidth(symbol)))
+		}
+	}
+	if e.stamped && !n.stampless && !Porcelain() {
+		stamp = n.timestamp(e.Time)
+	}
+	switch {
+	case symbol == "" || stamp == "":
+		return symbol + stamp
+	case n.symbolPos == SymbolAfterTime:
+		return stamp + " " + symbol
+	}
+	return symbol + " " + stamp
+}
@@ -0,0 +1,88 @@
+package aurora
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// tabPadding is the number of spaces left between aligned columns
+const tabPadding = 2
+
+// TabWriter buffers tab separated lines and prints them as aligned columns
+// Column widths ignore ANSI escape sequences, so colored cells line up
+// Obtain one with Notifier.Tabbed and call Flush once the rows are written
+type TabWriter struct {
+	mu      sync.Mutex   // Protects the pending rows
+	n       *Notifier    // Notifier receiving the aligned lines
+	level   LogLevel     // Level used to color the lines
+	partial bytes.Buffer // Bytes written after the last newline
+	rows    [][]string   // Complete rows split into cells
+}
+
+// Tabbed returns a writer that aligns tab separated output
+// Lines are held until Flush and then printed like Printf at the given level
+// A lightweight alternative to building a full table
+func (n *Notifier) Tabbed(level LogLevel) *TabWriter {
+	return &TabWriter{n: n, level: level}
+}
+
+// Write buffers p, splitting it into rows on newlines and cells on tabs
+// Always consumes all of p and never returns an error
+func (t *TabWriter) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.partial.Write(p)
+	for {
+		line, err := t.partial.ReadString('\n')
+		if err != nil {
+			// Keep the incomplete line for the next Write or Flush
+			t.partial.Reset()
+			t.partial.WriteString(line)
+			break
+		}
+		t.rows = append(t.rows, strings.Split(strings.TrimSuffix(line, "\n"), "\t"))
+	}
+	return len(p), nil
+}
+
+// Flush prints all buffered rows with their columns aligned
+// An unterminated last line is flushed as well
+func (t *TabWriter) Flush() error {
+	t.mu.Lock()
+	rows := t.rows
+	if t.partial.Len() > 0 {
+		rows = append(rows, strings.Split(t.partial.String(), "\t"))
+		t.partial.Reset()
+	}
+	t.rows = nil
+	t.mu.Unlock()
+
+	// The last cell of a row does not constrain its column
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row[:len(row)-1] {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			line.WriteString(cell)
+			if i < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)+tabPadding))
+			}
+		}
+		t.n.Printf(t.level, "%s", strings.TrimRight(line.String(), " "))
+	}
+	return nil
+}
+
+// Tabbed returns an aligning writer bound to the default Notifier
+// Convenient for quick tabular output
+func Tabbed(level LogLevel) *TabWriter { return Default.Tabbed(level) }
@@ -0,0 +1,108 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"strings"
+)
+
+// minColumnWidth is the narrowest a column is squeezed when a table is too wide
+const minColumnWidth = 3
+
+// cellStyle colors a single table cell; row is -1 for the header
+type cellStyle func(row, col int, cell string) string
+
+// renderTable lays out header and rows as aligned columns within maxWidth
+// Widest columns are truncated first when the table does not fit
+// Returns the rendered block including a separator under the header
+func renderTable(header []string, rows [][]string, maxWidth int, style cellStyle) string {
+	cols := len(header)
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	widths := make([]int, cols)
+	measure := func(row []string) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+	measure(header)
+	for _, row := range rows {
+		measure(row)
+	}
+
+	gap := tabPadding
+	if compact() {
+		gap = 1
+	}
+
+	// Shrink the widest column until the table fits or nothing can shrink
+	for total(widths)+gap*(cols-1) > maxWidth {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+	}
+
+	var b strings.Builder
+	writeRow := func(r int, row []string) {
+		var line strings.Builder
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = truncate(row[i], widths[i])
+			}
+			pad := widths[i] - displayWidth(cell)
+			if style != nil {
+				cell = style(r, i, cell)
+			}
+			line.WriteString(cell)
+			if i < cols-1 {
+				line.WriteString(strings.Repeat(" ", pad+gap))
+			}
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteByte('\n')
+	}
+
+	if len(header) > 0 {
+		writeRow(-1, header)
+		rules := make([]string, cols)
+		for i, w := range widths {
+			rules[i] = strings.Repeat("─", w)
+		}
+		b.WriteString(color.New(color.Faint).Sprint(strings.Join(rules, strings.Repeat(" ", gap))))
+		b.WriteByte('\n')
+	}
+	for r, row := range rows {
+		writeRow(r, row)
+	}
+	return b.String()
+}
+
+// total sums a slice of column widths
+func total(widths []int) int {
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	return sum
+}
+
+// writeBlock writes a pre-rendered multi-line block in a single locked write
+// Keeps tables and similar output from interleaving with other goroutines
+func (n *Notifier) writeBlock(block string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	fmt.Fprint(n.output, n.colorMode.apply(block))
+}
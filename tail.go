@@ -0,0 +1,94 @@
+package aurora
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// tailPollInterval is how often Tail checks the file for new content
+var tailPollInterval = 250 * time.Millisecond
+
+// Tail follows the file at path like tail -f, re-emitting new lines through n
+// Each line is leveled by the first matching rule and printed plain otherwise
+// Truncated or rotated files are reopened; returns nil once ctx is done
+func (n *Notifier) Tail(ctx context.Context, path string, rules []LevelRule) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { f.Close() }()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(f)
+	var partial string
+
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain everything currently available
+		for {
+			chunk, err := reader.ReadString('\n')
+			offset += int64(len(chunk))
+			if err != nil {
+				partial += chunk
+				if !errors.Is(err, io.EOF) {
+					return err
+				}
+				break
+			}
+			n.emitDetected(trimNewline(partial+chunk), rules)
+			partial = ""
+		}
+
+		select {
+		case <-ctx.Done():
+			if partial != "" {
+				n.emitDetected(partial, rules)
+			}
+			return nil
+		case <-ticker.C:
+		}
+
+		// Reopen from the start when the file was truncated or replaced
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		current, err := f.Stat()
+		if err == nil && os.SameFile(info, current) && info.Size() >= offset {
+			continue
+		}
+		next, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		f.Close()
+		f, offset, partial = next, 0, ""
+		reader.Reset(f)
+	}
+}
+
+// trimNewline removes a trailing line ending
+func trimNewline(s string) string {
+	if len(s) > 0 && s[len(s)-1] == '\n' {
+		s = s[:len(s)-1]
+	}
+	if len(s) > 0 && s[len(s)-1] == '\r' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// Tail follows a log file and recolors it through the default Notifier
+// Great for wrapping plain logs of legacy services
+func Tail(ctx context.Context, path string, rules []LevelRule) error {
+	return Default.Tail(ctx, path, rules)
+}
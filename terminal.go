@@ -0,0 +1,98 @@
+package aurora
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// isTerminal reports whether w is attached to a character device
+// Used to decide whether in-place updates like countdowns are safe
+// Anything that is not an *os.File is treated as a plain stream
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// defaultWidth is the column count assumed when the terminal size is unknown
+const defaultWidth = 80
+
+// terminalWidth returns the usable output width in columns
+// Honors the COLUMNS environment variable and falls back to defaultWidth
+func terminalWidth() int {
+	if v, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && v > 0 {
+		return v
+	}
+	return defaultWidth
+}
+
+// ciVariables are environment variables set by common CI systems
+var ciVariables = []string{"CI", "GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "BUILDKITE", "CIRCLECI", "TF_BUILD"}
+
+// animations overrides CI detection when set, guarded by mu
+var animations *bool
+
+// SetAnimations forces spinners, progress bars and other live output on or off
+// Overrides the automatic switch to plain lines on CI systems
+func SetAnimations(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	animations = &enabled
+}
+
+// inCI reports whether the process appears to run on a CI system
+// CI=false and similar explicit opt-outs are honored
+func inCI() bool {
+	for _, name := range ciVariables {
+		if v, ok := os.LookupEnv(name); ok && v != "" && v != "false" && v != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// animated reports whether live output may animate
+// True unless running on CI, with SetAnimations taking precedence
+func animated() bool {
+	mu.RLock()
+	override := animations
+	mu.RUnlock()
+	switch {
+	case Porcelain():
+		return false
+	case override != nil:
+		return *override
+	}
+	return !inCI()
+}
+
+// CompactTimeFormat is the timestamp layout used on narrow terminals
+const CompactTimeFormat = "15:04:05"
+
+// compactWidth is the width below which the compact profile applies
+// Zero disables it; guarded by mu
+var compactWidth int
+
+// SetCompactWidth enables the compact profile for terminals narrower than cols
+// It drops level symbols, shortens timestamps and tightens table columns
+// so split panes stay readable; use 0 to disable it
+func SetCompactWidth(cols int) {
+	mu.Lock()
+	defer mu.Unlock()
+	compactWidth = cols
+}
+
+// compact reports whether the compact profile is active
+func compact() bool {
+	mu.RLock()
+	limit := compactWidth
+	mu.RUnlock()
+	return limit > 0 && terminalWidth() < limit
+}
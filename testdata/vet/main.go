@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log"
+	"log/slog"
+	"testing"
+
+	au "github.com/olekukonko/aurora"
+)
+
+type job struct{ *au.Notifier }
+
+func main() {
+	n := au.New(nil)
+	n.Info("%s and %s", "one")
+	n.Logf(au.WarnLevel, "%d%% of %*d", 50, 4, 7)
+	au.Warn("%v", 1, 2)
+	n.Error("%[1]s", "x")
+	log.Printf("%s %s", "ignored")
+	args := []any{1}
+	n.Debug("%d", args...)
+	slog.Default().Info("request served", "status", 200)
+	job{n}.Success("done %d")
+	_ = au.Status(200)
+}
+
+func check(t *testing.T) {
+	t.Error("unexpected:", 42)
+}
@@ -0,0 +1,107 @@
+package aurora
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ansiPattern matches ANSI escape sequences such as color codes and OSC 8 links
+// Used to measure and copy text without terminal control characters
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// StripANSI removes ANSI escape sequences from s
+// Useful for measuring or storing colorized output as plain text
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// wideRanges lists East Asian wide and fullwidth runes plus emoji
+// These occupy two terminal columns, following go-runewidth
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo initials
+	{0x2E80, 0x303E},   // CJK radicals, punctuation
+	{0x3041, 0x33FF},   // Kana, CJK symbols
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // Transport and map symbols
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and pictographs extended A
+	{0x20000, 0x2FFFD}, // CJK extensions B and later
+	{0x30000, 0x3FFFD}, // CJK extension G
+}
+
+// runeWidth returns the number of terminal columns r occupies
+// Combining marks, control and format characters such as bidi marks
+// and zero width joiners take no space; wide runes take two
+func runeWidth(r rune) int {
+	if r < 0x20 || (r >= 0x7f && r < 0xa0) ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, span := range wideRanges {
+		if r < span[0] {
+			break
+		}
+		if r <= span[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// displayWidth returns the number of terminal columns s occupies
+// Escape sequences are ignored so colored text measures like plain text
+// Wide and zero width runes are measured with runeWidth
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range StripANSI(s) {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// truncate shortens plain text s to at most w columns
+// A trailing ellipsis marks text that was cut; wide runes are never split
+// and combining marks stay with the rune they modify
+func truncate(s string, w int) string {
+	if displayWidth(s) <= w {
+		return s
+	}
+	if w <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if width+rw > w-1 {
+			break
+		}
+		width += rw
+		b.WriteRune(r)
+	}
+	return b.String() + "…"
+}
+
+// humanBytes formats a byte count using binary units, e.g. "2.3 MB"
+func humanBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
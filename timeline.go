@@ -0,0 +1,91 @@
+package aurora
+
+import (
+	"github.com/fatih/color"
+	"strings"
+	"time"
+)
+
+// minTimelineBar keeps timeline bars readable on narrow terminals
+const minTimelineBar = 20
+
+// Span is a named stretch of time shown by Timeline
+type Span struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Spans returns the recorded steps as spans for Timeline
+// Spinners, Mux tasks and Record calls in the order they finished
+func (n *Notifier) Spans() []Span {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	spans := make([]Span, len(n.results.results))
+	for i, r := range n.results.results {
+		spans[i] = Span{Name: r.name, Start: r.start, Duration: r.took}
+	}
+	return spans
+}
+
+// Timeline draws spans as colored bars on a shared time axis, Gantt style
+// Bars are scaled to the terminal width and followed by their duration;
+// the axis below marks the start, middle and end of the whole run
+func (n *Notifier) Timeline(spans []Span) {
+	if len(spans) == 0 {
+		return
+	}
+	first, last := spans[0].Start, spans[0].Start.Add(spans[0].Duration)
+	names, took := 0, 0
+	for _, s := range spans {
+		if s.Start.Before(first) {
+			first = s.Start
+		}
+		if end := s.Start.Add(s.Duration); end.After(last) {
+			last = end
+		}
+		names = max(names, displayWidth(s.Name))
+		took = max(took, len(timelineDuration(s.Duration)))
+	}
+	total := last.Sub(first)
+	width := max(minTimelineBar, terminalWidth()-names-took-4)
+	column := func(t time.Time) int {
+		if total <= 0 {
+			return 0
+		}
+		return int(float64(t.Sub(first)) / float64(total) * float64(width))
+	}
+
+	var b strings.Builder
+	for i, s := range spans {
+		from := column(s.Start)
+		to := max(from+1, column(s.Start.Add(s.Duration)))
+		to = min(to, width)
+		from = min(from, to-1)
+		b.WriteString(s.Name + strings.Repeat(" ", names-displayWidth(s.Name)+2))
+		b.WriteString(strings.Repeat(" ", from))
+		b.WriteString(paint(n.force(prefixPalette[i%len(prefixPalette)]), strings.Repeat("█", to-from)))
+		b.WriteString(strings.Repeat(" ", width-to+2) + timelineDuration(s.Duration) + "\n")
+	}
+
+	start, middle, end := "0s", timelineDuration(total/2), timelineDuration(total)
+	axis := start + strings.Repeat(" ", max(1, width/2-len(start)-len(middle)/2)) + middle
+	axis += strings.Repeat(" ", max(1, width-displayWidth(axis)-len(end))) + end
+	b.WriteString(strings.Repeat(" ", names+2) + paint(n.force(color.New(color.Faint)), axis) + "\n")
+	n.writeBlock(b.String())
+}
+
+// timelineDuration rounds d for display next to bars and on the axis
+func timelineDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d >= time.Second:
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// Timeline draws spans on a time axis using the default Notifier
+// Shows where the time of a run went
+func Timeline(spans []Span) { Default.Timeline(spans) }
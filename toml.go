@@ -0,0 +1,389 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"github.com/fatih/color"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TOML color scheme for keys, tables and scalar values
+// Follows the palette used by the JSON and XML output
+var (
+	tomlKeyColor    = color.New(color.FgHiBlue)
+	tomlTableColor  = color.New(color.Bold, color.FgHiMagenta)
+	tomlStringColor = color.New(color.FgHiGreen)
+	tomlNumberColor = color.New(color.FgHiCyan)
+	tomlBoolColor   = color.New(color.FgHiYellow)
+)
+
+// tomlBareKey matches keys that can be written without quotes
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// tomlTable is an ordered TOML table built from a Go map or struct
+// Values are rendered scalars (string), sub-tables or arrays of tables
+type tomlTable struct {
+	keys   []string
+	values map[string]any
+}
+
+// TOML logs v as colorized TOML without title
+// v must be a struct or map since TOML documents are tables
+func (n *Notifier) TOML(v any) {
+	n.TOMLTitle("", v)
+}
+
+// TOMLE logs v as colorized TOML and returns marshal errors
+// Nothing is written when v cannot be encoded
+func (n *Notifier) TOMLE(v any) error {
+	return n.TOMLTitleE("", v)
+}
+
+// TOMLTitle logs v as colorized TOML with a title line
+// Handy for dumping loaded configuration
+func (n *Notifier) TOMLTitle(title string, v any) {
+	n.marshalFailed(n.TOMLTitleE(title, v))
+}
+
+// TOMLTitleE logs v as colorized TOML with a title line and returns errors
+// Lets callers handle configuration that cannot be represented
+func (n *Notifier) TOMLTitleE(title string, v any) error {
+	if title != "" {
+		n.Inlinef(DebugLevel, "%s: TOML ↴↴", title)
+	}
+	data, err := marshalTOML(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal TOML: %w", err)
+	}
+	n.writeBlock(string(limitDump(data)) + "\n")
+	return nil
+}
+
+// marshalTOML encodes v as a colorized TOML document
+// Struct fields honor `toml:"name,omitempty"` tags and "-" to skip
+func marshalTOML(v any) ([]byte, error) {
+	value, err := tomlValue(reflect.ValueOf(v), make(map[tomlRef]bool))
+	if err != nil {
+		return nil, err
+	}
+	table, ok := value.(*tomlTable)
+	if !ok {
+		return nil, fmt.Errorf("TOML requires a struct or map at the top level, got %T", v)
+	}
+	var b bytes.Buffer
+	table.write(&b, nil)
+	return b.Bytes(), nil
+}
+
+// tomlRef identifies a pointer, map or slice being encoded
+type tomlRef struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// tomlValue converts rv into a rendered scalar, *tomlTable or []*tomlTable
+// Returns nil for nil pointers and interfaces, which are omitted; seen holds
+// the references on the current path so cyclic values fail instead of recursing
+func tomlValue(rv reflect.Value, seen map[tomlRef]bool) (any, error) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() == reflect.Pointer {
+			if err := tomlEnter(rv, seen); err != nil {
+				return nil, err
+			}
+			defer delete(seen, tomlRef{rv.Pointer(), rv.Type()})
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if (rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && !rv.IsNil() {
+		if err := tomlEnter(rv, seen); err != nil {
+			return nil, err
+		}
+		defer delete(seen, tomlRef{rv.Pointer(), rv.Type()})
+	}
+
+	if rv.CanInterface() {
+		switch t := rv.Interface().(type) {
+		case time.Time:
+			return tomlNumberColor.Sprint(t.Format(time.RFC3339Nano)), nil
+		case encoding.TextMarshaler:
+			text, err := t.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return tomlString(string(text)), nil
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return tomlString(rv.String()), nil
+	case reflect.Bool:
+		return tomlBoolColor.Sprint(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return tomlNumberColor.Sprint(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return tomlNumberColor.Sprint(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return tomlNumberColor.Sprint(tomlFloat(rv.Float())), nil
+	case reflect.Map, reflect.Struct:
+		return tomlTableOf(rv, seen)
+	case reflect.Slice, reflect.Array:
+		return tomlArray(rv, seen)
+	}
+	return nil, fmt.Errorf("unsupported TOML type %s", rv.Type())
+}
+
+// tomlEnter adds the reference held by rv to seen
+// Fails when it is already there, i.e. rv contains itself
+func tomlEnter(rv reflect.Value, seen map[tomlRef]bool) error {
+	ref := tomlRef{rv.Pointer(), rv.Type()}
+	if seen[ref] {
+		return fmt.Errorf("TOML cannot encode cyclic value of type %s", rv.Type())
+	}
+	seen[ref] = true
+	return nil
+}
+
+// tomlTableOf builds a table from a map with string keys or a struct
+func tomlTableOf(rv reflect.Value, seen map[tomlRef]bool) (*tomlTable, error) {
+	table := &tomlTable{values: make(map[string]any)}
+	set := func(key string, fv reflect.Value) error {
+		value, err := tomlValue(fv, seen)
+		if err != nil || value == nil {
+			return err
+		}
+		if _, exists := table.values[key]; !exists {
+			table.keys = append(table.keys, key)
+		}
+		table.values[key] = value
+		return nil
+	}
+
+	if rv.Kind() == reflect.Map {
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			if err := set(fmt.Sprint(k), rv.MapIndex(k)); err != nil {
+				return nil, err
+			}
+		}
+		return table, nil
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+		if opts == "omitempty" && fv.IsZero() {
+			continue
+		}
+		// Untagged embedded structs are flattened like encoding/json does
+		if field.Anonymous && name == "" && reflect.Indirect(fv).Kind() == reflect.Struct {
+			embedded, err := tomlValue(fv, seen)
+			if err != nil {
+				return nil, err
+			}
+			if sub, ok := embedded.(*tomlTable); ok {
+				for _, key := range sub.keys {
+					table.keys = append(table.keys, key)
+					table.values[key] = sub.values[key]
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if err := set(name, fv); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+// tomlArray converts a slice into an array of tables or an inline array
+func tomlArray(rv reflect.Value, seen map[tomlRef]bool) (any, error) {
+	items := make([]any, 0, rv.Len())
+	tables := make([]*tomlTable, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		item, err := tomlValue(rv.Index(i), seen)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			continue
+		}
+		if table, ok := item.(*tomlTable); ok {
+			tables = append(tables, table)
+		}
+		items = append(items, item)
+	}
+	if len(items) > 0 && len(tables) == len(items) {
+		return tables, nil
+	}
+
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = tomlInline(item)
+	}
+	return "[" + strings.Join(parts, ", ") + "]", nil
+}
+
+// tomlInline renders any converted value on a single line
+// Tables become inline tables, which arrays of mixed values require
+func tomlInline(v any) string {
+	switch t := v.(type) {
+	case *tomlTable:
+		parts := make([]string, len(t.keys))
+		for i, key := range t.keys {
+			parts[i] = tomlKey(key) + " = " + tomlInline(t.values[key])
+		}
+		if len(parts) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(parts, ", ") + " }"
+	case []*tomlTable:
+		parts := make([]string, len(t))
+		for i, table := range t {
+			parts[i] = tomlInline(table)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return v.(string)
+}
+
+// write renders the table at path: scalars first, then sub-tables
+// and finally arrays of tables, as TOML requires
+func (t *tomlTable) write(b *bytes.Buffer, path []string) {
+	for _, key := range t.keys {
+		if value, ok := t.values[key].(string); ok {
+			b.WriteString(tomlKey(key) + " = " + value + "\n")
+		}
+	}
+
+	header := func(open, close string, key string) {
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		parts := make([]string, 0, len(path)+1)
+		for _, p := range append(path, key) {
+			parts = append(parts, tomlKeyText(p))
+		}
+		b.WriteString(tomlTableColor.Sprint(open+strings.Join(parts, ".")+close) + "\n")
+	}
+
+	for _, key := range t.keys {
+		if sub, ok := t.values[key].(*tomlTable); ok {
+			header("[", "]", key)
+			sub.write(b, append(path[:len(path):len(path)], key))
+		}
+	}
+	for _, key := range t.keys {
+		if subs, ok := t.values[key].([]*tomlTable); ok {
+			for _, sub := range subs {
+				header("[[", "]]", key)
+				sub.write(b, append(path[:len(path):len(path)], key))
+			}
+		}
+	}
+}
+
+// tomlKey renders a colorized key, quoting it when necessary
+func tomlKey(key string) string {
+	return tomlKeyColor.Sprint(tomlKeyText(key))
+}
+
+// tomlKeyText returns key as written in a document without color
+func tomlKeyText(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return tomlQuote(key)
+}
+
+// tomlString renders a colorized basic string
+func tomlString(s string) string {
+	return tomlStringColor.Sprint(tomlQuote(s))
+}
+
+// tomlQuote escapes s as a TOML basic string
+// Only escapes defined by the TOML specification are produced
+func tomlQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// tomlFloat formats f so it always reads back as a float
+func tomlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEn") {
+		s += ".0"
+	}
+	return s
+}
+
+// TOML logs v as colorized TOML using the default Notifier
+// Quick configuration dumping
+func TOML(v any) { Default.TOML(v) }
+
+// TOMLE logs v as colorized TOML using the default Notifier and returns errors
+// Configuration dumping for callers that handle failures
+func TOMLE(v any) error { return Default.TOMLE(v) }
+
+// TOMLTitle logs v as colorized TOML with a title using the default Notifier
+// Configuration dumping with context
+func TOMLTitle(title string, v any) { Default.TOMLTitle(title, v) }
@@ -0,0 +1,156 @@
+package aurora
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/fatih/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Colors of the update banner
+var (
+	updateBorder = color.New(color.Faint)
+	updateOld    = color.New(color.FgRed)
+	updateNew    = color.New(color.FgGreen, color.Bold)
+	updateLink   = color.New(color.FgCyan, color.Underline)
+)
+
+// updateConfig holds the settings of a single UpdateAvailable call
+type updateConfig struct {
+	every  time.Duration // Minimum time between banners for the same release
+	file   string        // Where the last banner is remembered, empty to not remember
+	custom bool          // Whether file was set with ReminderFile
+}
+
+// UpdateOption configures an UpdateAvailable banner
+type UpdateOption func(*updateConfig)
+
+// RemindEvery sets how long the banner stays quiet after it was shown, a day by default
+// A newer release is announced right away; zero or less shows it every time
+func RemindEvery(d time.Duration) UpdateOption {
+	return func(c *updateConfig) { c.every = d }
+}
+
+// ReminderFile sets the file remembering when the banner was last shown
+// Defaults to a file named after the program in the user cache directory
+func ReminderFile(path string) UpdateOption {
+	return func(c *updateConfig) { c.file, c.custom = path, true }
+}
+
+// updateReminder is the content of the reminder file
+type updateReminder struct {
+	Latest string    `json:"latest"`
+	Shown  time.Time `json:"shown"`
+}
+
+// UpdateAvailable shows a boxed banner announcing latest as the successor of
+// current, with a link to url; nothing is shown when the versions match, in
+// porcelain mode or when the same release was announced within a day
+// The banner is a Notice level diagnostic, so POSIXStreams send it to stderr
+func (n *Notifier) UpdateAvailable(current, latest, url string, opts ...UpdateOption) {
+	cfg := updateConfig{every: 24 * time.Hour}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if latest == "" || strings.TrimPrefix(current, "v") == strings.TrimPrefix(latest, "v") || Porcelain() {
+		return
+	}
+	if !cfg.custom {
+		cfg.file = defaultReminderFile()
+	}
+	now := n.now()
+	if !cfg.due(latest, now) {
+		return
+	}
+	hyperlinks := n.Capabilities().Hyperlinks
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	lines := []string{fmt.Sprintf(tr("Update available %s → %s"), paint(n.force(updateOld), current), paint(n.force(updateNew), latest))}
+	if url != "" {
+		link := url
+		if hyperlinks {
+			link = osc8(url, url)
+		}
+		lines = append(lines, paint(n.force(updateLink), link))
+	}
+	banner := frame(lines, n.force(updateBorder))
+	n.output.routeLevel(NoticeLevel, ConsoleSink, []byte(n.colorMode.apply(banner)), nil)
+	cfg.remember(latest, now)
+}
+
+// due reports whether latest may be announced at now
+// Unreadable reminders count as never shown
+func (c updateConfig) due(latest string, now time.Time) bool {
+	if c.file == "" || c.every <= 0 {
+		return true
+	}
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return true
+	}
+	var last updateReminder
+	if json.Unmarshal(data, &last) != nil || last.Latest != latest {
+		return true
+	}
+	return now.Sub(last.Shown) >= c.every
+}
+
+// remember records that latest was announced at now, best effort
+func (c updateConfig) remember(latest string, now time.Time) {
+	if c.file == "" {
+		return
+	}
+	data, err := json.Marshal(updateReminder{Latest: latest, Shown: now})
+	if err != nil || os.MkdirAll(filepath.Dir(c.file), 0o755) != nil {
+		return
+	}
+	_ = os.WriteFile(c.file, data, 0o644)
+}
+
+// defaultReminderFile returns the reminder file of the running program
+// e.g. ~/.cache/aurora/update-mytool.json; empty without a cache directory
+func defaultReminderFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	name := strings.TrimSuffix(filepath.Base(os.Args[0]), filepath.Ext(os.Args[0]))
+	return filepath.Join(dir, "aurora", "update-"+name+".json")
+}
+
+// frame draws a rounded border in border color around lines, centered with
+// a blank line above and below the way update banners usually look
+func frame(lines []string, border *color.Color) string {
+	width := 0
+	for _, line := range lines {
+		width = max(width, displayWidth(line))
+	}
+	width += 6
+
+	side := paint(border, "│")
+	var b strings.Builder
+	b.WriteString(paint(border, "╭"+strings.Repeat("─", width)+"╮") + "\n")
+	b.WriteString(side + strings.Repeat(" ", width) + side + "\n")
+	for _, line := range lines {
+		pad := width - displayWidth(line)
+		b.WriteString(side + strings.Repeat(" ", pad/2) + line + strings.Repeat(" ", pad-pad/2) + side + "\n")
+	}
+	b.WriteString(side + strings.Repeat(" ", width) + side + "\n")
+	b.WriteString(paint(border, "╰"+strings.Repeat("─", width)+"╯") + "\n")
+	return b.String()
+}
+
+// osc8 returns text as a terminal hyperlink to url
+func osc8(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// UpdateAvailable shows an update banner using the default Notifier
+// e.g. aurora.UpdateAvailable(version, release.Tag, release.URL)
+func UpdateAvailable(current, latest, url string, opts ...UpdateOption) {
+	Default.UpdateAvailable(current, latest, url, opts...)
+}
@@ -0,0 +1,153 @@
+package aurora
+
+import (
+	"flag"
+	"fmt"
+	"github.com/fatih/color"
+	"strconv"
+	"strings"
+)
+
+// Colors of usage output
+var (
+	usageHeading = color.New(color.Bold, color.FgHiCyan)
+	usageFlag    = color.New(color.FgHiYellow)
+)
+
+// UsageFlag is a flag listed by Usage
+type UsageFlag struct {
+	Name    string // Long name without dashes, e.g. "output"
+	Short   string // Single letter alias without the dash, empty if none
+	Arg     string // Placeholder of the value, e.g. "file"; empty for switches
+	Usage   string // One line description
+	Default string // Default value shown after the description, empty to hide
+}
+
+// UsageCommand is a subcommand listed by Usage
+type UsageCommand struct {
+	Name    string
+	Summary string
+}
+
+// UsageSpec describes the help text of a command
+type UsageSpec struct {
+	Name     string         // Command path, e.g. "app deploy"
+	Summary  string         // One line description shown first
+	Usage    []string       // Invocation forms, e.g. "app deploy [flags] <env>"
+	Commands []UsageCommand // Subcommands
+	Flags    []UsageFlag
+	Examples []string // Command lines shown as code blocks
+}
+
+// Usage prints help for spec in sections: USAGE, COMMANDS, FLAGS and EXAMPLES
+// Flag descriptions are aligned and examples shaded like Command, so help
+// text looks like the rest of the tool's output; empty sections are left out
+func (n *Notifier) Usage(spec UsageSpec) {
+	n.mu.Lock()
+	heading, flagColor, code := n.force(usageHeading), n.force(usageFlag), n.force(commandStyle)
+	bold, faint := n.force(color.New(color.Bold)), n.force(color.New(color.Faint))
+	n.mu.Unlock()
+
+	var sections []string
+	if spec.Name != "" || spec.Summary != "" {
+		head := paint(bold, spec.Name)
+		if spec.Name != "" && spec.Summary != "" {
+			head += " - "
+		}
+		sections = append(sections, head+spec.Summary+"\n")
+	}
+	section := func(title string, lines []string) {
+		if len(lines) > 0 {
+			sections = append(sections, paint(heading, tr(title))+"\n"+strings.Join(lines, ""))
+		}
+	}
+
+	var lines []string
+	for _, u := range spec.Usage {
+		lines = append(lines, "  "+u+"\n")
+	}
+	section("USAGE", lines)
+
+	lines = nil
+	width := 0
+	for _, c := range spec.Commands {
+		width = max(width, displayWidth(c.Name))
+	}
+	for _, c := range spec.Commands {
+		lines = append(lines, "  "+paint(flagColor, c.Name)+strings.Repeat(" ", width-displayWidth(c.Name))+"   "+c.Summary+"\n")
+	}
+	section("COMMANDS", lines)
+
+	lines = nil
+	names := make([]string, len(spec.Flags))
+	width = 0
+	for i, f := range spec.Flags {
+		names[i] = flagSignature(f)
+		width = max(width, displayWidth(names[i]))
+	}
+	for i, f := range spec.Flags {
+		line := "  " + paint(flagColor, names[i]) + strings.Repeat(" ", width-displayWidth(names[i])) + "   " + f.Usage
+		if f.Default != "" {
+			line += " " + paint(faint, "("+tr("default")+" "+f.Default+")")
+		}
+		lines = append(lines, line+"\n")
+	}
+	section("FLAGS", lines)
+
+	lines = nil
+	for _, ex := range spec.Examples {
+		lines = append(lines, "  "+paint(code, " $ "+ex+" ")+"\n")
+	}
+	section("EXAMPLES", lines)
+
+	n.writeBlock(strings.Join(sections, "\n"))
+}
+
+// flagSignature renders the names and placeholder of f, e.g. "-o, --output file"
+// Flags without a short alias are indented to line up with those that have one
+func flagSignature(f UsageFlag) string {
+	s := "    "
+	if f.Short != "" {
+		s = "-" + f.Short + ", "
+	}
+	if f.Name != "" {
+		s += "--" + f.Name
+	} else {
+		s = strings.TrimSuffix(s, ", ")
+	}
+	if f.Arg != "" {
+		s += " " + f.Arg
+	}
+	return s
+}
+
+// FlagSetUsage describes the flags of fs for Usage
+// Single letter flags become short aliases; zero defaults are hidden
+// and string defaults quoted the way flag.PrintDefaults does
+func FlagSetUsage(fs *flag.FlagSet) UsageSpec {
+	spec := UsageSpec{Name: fs.Name()}
+	if fs.Name() != "" {
+		spec.Usage = []string{fs.Name() + " [flags]"}
+	}
+	fs.VisitAll(func(f *flag.Flag) {
+		arg, usage := flag.UnquoteUsage(f)
+		uf := UsageFlag{Name: f.Name, Arg: arg, Usage: usage}
+		if len(f.Name) == 1 {
+			uf.Name, uf.Short = "", f.Name
+		}
+		switch f.DefValue {
+		case "", "0", "false", "[]", "<nil>":
+		default:
+			uf.Default = f.DefValue
+			if fmt.Sprintf("%T", f.Value) == "*flag.stringValue" {
+				uf.Default = strconv.Quote(f.DefValue)
+			}
+		}
+		spec.Flags = append(spec.Flags, uf)
+	})
+	return spec
+}
+
+// Usage prints help for spec using the default Notifier
+// e.g. fs.Usage = func() { aurora.Usage(aurora.FlagSetUsage(fs)) }
+func Usage(spec UsageSpec) { Default.Usage(spec) }
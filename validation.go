@@ -0,0 +1,145 @@
+package aurora
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"reflect"
+	"strings"
+)
+
+// Colors of validation output
+var (
+	fieldPathColor = color.New(color.Bold)
+	fieldBullet    = color.New(color.FgRed)
+)
+
+// validationValueWidth caps the columns of an offending value preview
+const validationValueWidth = 40
+
+// FieldError is a validation failure of a single field
+type FieldError struct {
+	Path    string // Field path, e.g. "user.emails[0]"
+	Message string // What is wrong, e.g. "must be a valid email"
+	Value   any    // Offending value, nil when unknown
+}
+
+// ValidationErrors prints errs grouped by field path below an error count
+// Paths keep the order they first appear in; each message shows the
+// offending value as a short preview
+func (n *Notifier) ValidationErrors(errs []FieldError) {
+	if len(errs) == 0 {
+		return
+	}
+	var paths []string
+	groups := make(map[string][]FieldError)
+	for _, fe := range errs {
+		if _, ok := groups[fe.Path]; !ok {
+			paths = append(paths, fe.Path)
+		}
+		groups[fe.Path] = append(groups[fe.Path], fe)
+	}
+
+	n.mu.Lock()
+	bold, bullet, faint := n.force(fieldPathColor), n.force(fieldBullet), n.force(color.New(color.Faint))
+	n.mu.Unlock()
+	var b strings.Builder
+	for _, path := range paths {
+		label := path
+		if label == "" {
+			label = tr("(root)")
+		}
+		b.WriteString("  " + paint(bold, label) + "\n")
+		for _, fe := range groups[path] {
+			b.WriteString("    " + paint(bullet, "•") + " " + fe.Message)
+			if fe.Value != nil {
+				b.WriteString(" " + paint(faint, tr("got")) + " " + Preview(fe.Value, validationValueWidth).String())
+			}
+			b.WriteString("\n")
+		}
+	}
+	n.Inlinef(ErrorLevel, "%d %s", len(errs), plural(len(errs), "validation error", "validation errors"))
+	n.writeBlock(b.String())
+}
+
+// validatorError has the methods of a go-playground/validator FieldError
+type validatorError interface {
+	Namespace() string
+	Tag() string
+	Param() string
+	Value() any
+}
+
+// validatorMessages phrase common validator tags, %s is the tag parameter
+var validatorMessages = map[string]string{
+	"required": "is required",
+	"email":    "must be a valid email",
+	"url":      "must be a valid URL",
+	"min":      "must be at least %s",
+	"max":      "must be at most %s",
+	"len":      "must have length %s",
+	"oneof":    "must be one of %s",
+	"gt":       "must be greater than %s",
+	"gte":      "must be at least %s",
+	"lt":       "must be less than %s",
+	"lte":      "must be at most %s",
+}
+
+// FromValidator converts the errors of a go-playground/validator call
+// Pass the error returned by Struct or Var; errors of any other kind
+// yield nil. Paths drop the struct name, e.g. "User.Email" becomes "Email"
+func FromValidator(err error) []FieldError {
+	v := reflect.ValueOf(err)
+	if err == nil || v.Kind() != reflect.Slice {
+		return nil
+	}
+	var errs []FieldError
+	for i := 0; i < v.Len(); i++ {
+		fe, ok := v.Index(i).Interface().(validatorError)
+		if !ok {
+			return nil
+		}
+		path := fe.Namespace()
+		if _, rest, found := strings.Cut(path, "."); found {
+			path = rest
+		}
+		msg, ok := validatorMessages[fe.Tag()]
+		switch {
+		case !ok && fe.Param() != "":
+			msg = fmt.Sprintf(tr("failed %s=%s"), fe.Tag(), fe.Param())
+		case !ok:
+			msg = fmt.Sprintf(tr("failed %s"), fe.Tag())
+		case strings.Contains(msg, "%s"):
+			msg = fmt.Sprintf(tr(msg), fe.Param())
+		default:
+			msg = tr(msg)
+		}
+		errs = append(errs, FieldError{Path: path, Message: msg, Value: fe.Value()})
+	}
+	return errs
+}
+
+// SchemaError has the methods of a JSON Schema result error,
+// e.g. gojsonschema.ResultError
+type SchemaError interface {
+	Field() string
+	Description() string
+	Value() any
+}
+
+// FromJSONSchema converts JSON Schema validation errors
+// The "(root)" field of errors about the whole document becomes ""
+func FromJSONSchema[E SchemaError](errs []E) []FieldError {
+	converted := make([]FieldError, 0, len(errs))
+	for _, e := range errs {
+		path := e.Field()
+		if path == "(root)" {
+			path = ""
+		}
+		converted = append(converted, FieldError{Path: path, Message: e.Description(), Value: e.Value()})
+	}
+	return converted
+}
+
+// ValidationErrors prints grouped validation errors using the default Notifier
+// Friendly output for API servers and config loaders
+func ValidationErrors(errs []FieldError) { Default.ValidationErrors(errs) }
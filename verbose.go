@@ -0,0 +1,97 @@
+package aurora
+
+// defaultBacklogSize is the number of hidden verbose lines kept for DumpOnError
+const defaultBacklogSize = 500
+
+// backlog is a ring buffer of output hidden by Verbose
+// Oldest lines are overwritten once it is full
+// Guarded by the Notifier mutex
+type backlog struct {
+	lines []string
+	next  int  // Slot the next line is written to
+	full  bool // Whether the ring has wrapped at least once
+}
+
+// newBacklog creates a backlog holding up to size lines
+func newBacklog(size int) *backlog {
+	return &backlog{lines: make([]string, size)}
+}
+
+// Write stores p as the newest entry, replacing the oldest when full
+// Notifier writes are whole lines, so each call is kept as one entry
+func (b *backlog) Write(p []byte) (int, error) {
+	if len(b.lines) == 0 {
+		return len(p), nil
+	}
+	b.lines[b.next] = string(p)
+	b.next = (b.next + 1) % len(b.lines)
+	if b.next == 0 {
+		b.full = true
+	}
+	return len(p), nil
+}
+
+// drain returns the stored entries oldest first and empties the ring
+func (b *backlog) drain() []string {
+	var out []string
+	if b.full {
+		out = append(out, b.lines[b.next:]...)
+	}
+	out = append(out, b.lines[:b.next]...)
+	clear(b.lines)
+	b.next, b.full = 0, false
+	return out
+}
+
+// DumpOnError writes the hidden verbose output when err is not nil
+// The output is preceded by a notice and the backlog is cleared
+// Typical use is a deferred call in main with the final error
+func (n *Notifier) DumpOnError(err error) {
+	if err == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	lines := n.backlog.drain()
+	if len(lines) == 0 {
+		return
+	}
+	n.write(n.newEntry(NoticeLevel, tr("verbose output before error:")))
+	for _, line := range lines {
+		n.output.Write([]byte(line))
+	}
+}
+
+// SetVerbose sets whether Verbose blocks are written immediately
+// Usually wired to a -v flag
+func (n *Notifier) SetVerbose(enabled bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.verbose = enabled
+}
+
+// Verbose runs fn with a Notifier for detailed output
+// When verbosity is off the output is kept in the backlog instead,
+// so DumpOnError can still reveal it if the program fails
+func (n *Notifier) Verbose(fn func(v *Notifier)) {
+	n.mu.Lock()
+	v := n.derive()
+	if !n.verbose {
+		v.output = newSwitchWriter(n.backlog, n.mu)
+	}
+	n.mu.Unlock()
+	fn(v)
+}
+
+// DumpOnError writes hidden verbose output of the default Notifier when err is not nil
+// Full detail only when something went wrong
+func DumpOnError(err error) { Default.DumpOnError(err) }
+
+// SetVerbose sets whether Verbose blocks of the default Notifier are written
+// Usually wired to a -v flag
+func SetVerbose(enabled bool) { Default.SetVerbose(enabled) }
+
+// Verbose runs fn with a Notifier for detailed output using the default Notifier
+// Hidden output is kept for DumpOnError
+func Verbose(fn func(v *Notifier)) { Default.Verbose(fn) }
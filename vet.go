@@ -0,0 +1,216 @@
+package aurora
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// importPath identifies aurora imports when vetting source files
+const importPath = "github.com/olekukonko/aurora"
+
+// formatIndex gives the position of the format argument of printf-style
+// functions and methods, e.g. Info(format, ...) and Logf(level, format, ...)
+var formatIndex = map[string]int{
+	"Alert": 0, "Critical": 0, "Debug": 0, "Error": 0, "Info": 0, "Notice": 0, "Warn": 0,
+	"Codef": 0, "Failure": 0, "Fail": 0, "Highlight": 0, "Msg": 0, "Panic": 0, "Step": 0, "Success": 0,
+	"Color": 1, "Ephemeral": 1, "Format": 1, "Inlinef": 1, "Logf": 1, "Printf": 1, "Status": 1,
+	"If": 2,
+}
+
+// VetFormats checks aurora calls in the Go files under root for format
+// verbs that do not match the argument count, like go vet does for fmt
+// Packages importing aurora are type-checked with the go command, so only
+// calls resolving to aurora and literal formats are checked, e.g. in a test:
+//
+//	if err := aurora.VetFormats("."); err != nil {
+//		t.Fatal(err)
+//	}
+func VetFormats(root string) error {
+	var errs []error
+	fset := token.NewFileSet()
+	packages := make(map[string][]*ast.File) // Files by directory and package name
+	var order []string
+	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		key := filepath.Dir(path) + " " + file.Name.Name
+		if _, seen := packages[key]; !seen {
+			order = append(order, key)
+		}
+		packages[key] = append(packages[key], file)
+		return nil
+	})
+	if walkErr != nil {
+		errs = append(errs, walkErr)
+	}
+
+	for _, key := range order {
+		files := packages[key]
+		if !slices.ContainsFunc(files, importsAurora) {
+			continue
+		}
+		dir, _, _ := strings.Cut(key, " ")
+		info := &types.Info{Uses: make(map[*ast.Ident]types.Object), Selections: make(map[*ast.SelectorExpr]*types.Selection)}
+		conf := types.Config{
+			Importer: importer.ForCompiler(fset, "gc", exportData(dir)),
+			Error:    func(error) {}, // Unresolved calls are skipped instead
+		}
+		conf.Check(files[0].Name.Name, fset, files, info)
+		for _, file := range files {
+			errs = append(errs, vetFile(fset, file, info)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// vetFile reports mismatched formats in the aurora calls of file
+// Calls are resolved through info; anything not declared by aurora is skipped
+func vetFile(fset *token.FileSet, file *ast.File, info *types.Info) []error {
+	var errs []error
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || call.Ellipsis.IsValid() {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		index, ok := formatIndex[sel.Sel.Name]
+		if !ok || len(call.Args) <= index || !printfLike(callee(info, sel), index) {
+			return true
+		}
+		lit, ok := call.Args[index].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		format, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		want, ok := countVerbs(format)
+		if got := len(call.Args) - index - 1; ok && got != want {
+			noun := "args"
+			if want == 1 {
+				noun = "arg"
+			}
+			errs = append(errs, fmt.Errorf("%s: %s format %q expects %d %s, got %d",
+				fset.Position(call.Pos()), sel.Sel.Name, format, want, noun, got))
+		}
+		return true
+	})
+	return errs
+}
+
+// exportData returns a lookup of the compiled export data of every package
+// the package in dir and its tests depend on, built by "go list -export"
+// Imports are unresolved, and their calls skipped, when go list fails
+func exportData(dir string) importer.Lookup {
+	cmd := exec.Command("go", "list", "-e", "-export", "-deps", "-test", "-f", "{{.ImportPath}}={{.Export}}", ".")
+	cmd.Dir = dir
+	out, _ := cmd.Output()
+	exports := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, file, ok := strings.Cut(line, "="); ok && file != "" {
+			exports[path] = file
+		}
+	}
+	return func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(file)
+	}
+}
+
+// importsAurora reports whether file imports aurora
+func importsAurora(file *ast.File) bool {
+	return slices.ContainsFunc(file.Imports, func(spec *ast.ImportSpec) bool {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		return path == importPath
+	})
+}
+
+// callee returns the aurora function or method sel refers to, nil otherwise
+// Methods promoted from an embedded aurora type count as aurora's
+func callee(info *types.Info, sel *ast.SelectorExpr) *types.Func {
+	obj := info.Uses[sel.Sel]
+	if s, ok := info.Selections[sel]; ok {
+		obj = s.Obj()
+	}
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != importPath {
+		return nil
+	}
+	return fn
+}
+
+// printfLike reports whether fn takes a format string at index followed by ...any
+func printfLike(fn *types.Func, index int) bool {
+	if fn == nil {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if !sig.Variadic() || sig.Params().Len() != index+2 {
+		return false
+	}
+	format, ok := sig.Params().At(index).Type().(*types.Basic)
+	return ok && format.Kind() == types.String
+}
+
+// countVerbs returns the number of arguments format consumes
+// Formats with explicit argument indexes like %[1]d are not counted
+func countVerbs(format string) (int, bool) {
+	count := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		for i++; i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0; i++ {
+		}
+		for ; i < len(format) && strings.IndexByte("0123456789.*", format[i]) >= 0; i++ {
+			if format[i] == '*' {
+				count++
+			}
+		}
+		switch {
+		case i == len(format):
+			return count, true
+		case format[i] == '[':
+			return 0, false
+		case format[i] != '%':
+			count++
+		}
+	}
+	return count, true
+}
@@ -0,0 +1,41 @@
+package aurora
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// WithWorker returns a derived Notifier labelling every entry with id
+// e.g. pool.WithWorker("w3") untangles interleaved output of a worker pool;
+// the label follows the prefix and wins over WithGoroutineID
+func (n *Notifier) WithWorker(id string) *Notifier {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	child := n.derive()
+	child.worker = id
+	return child
+}
+
+// WithGoroutineID labels entries with the ID of the logging goroutine, e.g. "g17"
+// Meant for debugging sessions; reading the ID costs a stack trace per entry
+func WithGoroutineID() Option {
+	return func(n *Notifier) { n.goroutineIDs = true }
+}
+
+// goroutineID returns the ID of the calling goroutine
+// Parsed from the first line of its stack trace, "goroutine 17 [running]:"
+func goroutineID() uint64 {
+	var buf [64]byte
+	line := buf[:runtime.Stack(buf[:], false)]
+	line = bytes.TrimPrefix(line, []byte("goroutine "))
+	if i := bytes.IndexByte(line, ' '); i > 0 {
+		line = line[:i]
+	}
+	id, _ := strconv.ParseUint(string(line), 10, 64)
+	return id
+}
+
+// WithWorker returns a Notifier derived from the default one labelled with id
+// Helps tell concurrent workers apart
+func WithWorker(id string) *Notifier { return Default.WithWorker(id) }
@@ -0,0 +1,103 @@
+package aurora
+
+import (
+	"io"
+	"time"
+)
+
+// timeoutWriter gives console writes a deadline so a blocked pipe cannot hang logging
+// Writes happen on a worker goroutine; one that misses the deadline keeps
+// running, and everything written until it finishes is dropped and counted
+// Guarded by the Notifier mutex
+type timeoutWriter struct {
+	w       io.Writer
+	timeout time.Duration
+	reqs    chan []byte // Writes handed to the worker
+	done    chan error  // Result of each finished write
+	pending bool        // Whether a write missed its deadline and is still running
+	dropped int         // Writes dropped while a write was pending
+}
+
+// newTimeoutWriter starts the worker writing to w
+func newTimeoutWriter(w io.Writer, timeout time.Duration) *timeoutWriter {
+	t := &timeoutWriter{
+		w:       w,
+		timeout: timeout,
+		reqs:    make(chan []byte),
+		done:    make(chan error, 1),
+	}
+	go t.work()
+	return t
+}
+
+// work writes every request to w until stop closes the request channel
+func (t *timeoutWriter) work() {
+	for p := range t.reqs {
+		_, err := t.w.Write(p)
+		t.done <- err
+	}
+}
+
+// Write passes p to the worker and waits at most the timeout for it
+// p is copied since a late write may still be running after Write returns
+func (t *timeoutWriter) Write(p []byte) (int, error) {
+	if t.pending {
+		select {
+		case <-t.done:
+			t.pending = false
+		default:
+			t.dropped++
+			return len(p), nil
+		}
+	}
+
+	t.reqs <- append([]byte(nil), p...)
+	timer := time.NewTimer(t.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-t.done:
+		if err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	case <-timer.C:
+		t.pending = true
+		return len(p), nil
+	}
+}
+
+// stop ends the worker once its current write, if any, has finished
+func (t *timeoutWriter) stop() {
+	close(t.reqs)
+}
+
+// stalled returns the console writes dropped by the write deadline
+func (s *switchWriter) stalled() int {
+	if s.stall == nil {
+		return 0
+	}
+	return s.stall.dropped
+}
+
+// SetWriteTimeout limits how long a console write may block, e.g. on a full pipe
+// Output written while a timed out write is still stuck is dropped and counted
+// in Stats().Stalled instead of holding up the program; zero turns it off
+func (n *Notifier) SetWriteTimeout(d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s := n.output
+	var dropped int
+	if s.stall != nil {
+		dropped = s.stall.dropped
+		s.stall.stop()
+		s.stall = nil
+	}
+	if d > 0 {
+		s.stall = newTimeoutWriter(s.w, d)
+		s.stall.dropped = dropped
+	}
+}
+
+// SetWriteTimeout limits how long console writes of the default Notifier may block
+// Keeps a stuck stdout pipe from hanging the application
+func SetWriteTimeout(d time.Duration) { Default.SetWriteTimeout(d) }
@@ -0,0 +1,222 @@
+package aurora
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/fatih/color"
+	"io"
+	"strings"
+)
+
+// XML color scheme used when highlighting markup
+// Mirrors the key/value distinction used by the JSON output
+var (
+	xmlTagColor     = color.New(color.FgHiBlue)
+	xmlAttrColor    = color.New(color.FgHiCyan)
+	xmlValueColor   = color.New(color.FgHiGreen)
+	xmlCommentColor = color.New(color.Faint)
+)
+
+// XML logs values as highlighted XML without title
+// Uses two space indentation since unindented XML is hard to read
+func (n *Notifier) XML(values ...any) {
+	n.XMLIndent("", IndentSpace2, values...)
+}
+
+// XMLBytes pretty-prints and highlights an existing XML document
+// Useful for SOAP envelopes or responses captured from legacy APIs
+func (n *Notifier) XMLBytes(b []byte) {
+	n.marshalFailed(n.XMLBytesE(b))
+}
+
+// XMLBytesE pretty-prints an XML document and returns parse errors
+// Nothing but the trailing blank line is written for malformed input
+func (n *Notifier) XMLBytesE(b []byte) error {
+	return n.writeXML("", IndentSpace2, [][]byte{b}, nil)
+}
+
+// XMLE logs values as highlighted XML and returns marshal errors
+// Lets library code react to serialization failures
+func (n *Notifier) XMLE(values ...any) error {
+	return n.XMLIndentE("", IndentSpace2, values...)
+}
+
+// XMLIndent logs values as highlighted XML with custom indentation
+// Accepts the same Indent constants as JSONIndent
+func (n *Notifier) XMLIndent(title string, indent string, values ...any) {
+	n.marshalFailed(n.XMLIndentE(title, indent, values...))
+}
+
+// XMLIndentE logs values as XML with custom indentation and returns errors
+// Values that fail are skipped while the rest are still written
+func (n *Notifier) XMLIndentE(title string, indent string, values ...any) error {
+	docs := make([][]byte, 0, len(values))
+	var errs []error
+	for _, v := range values {
+		data, err := xml.Marshal(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to marshal XML: %w", err))
+			continue
+		}
+		docs = append(docs, data)
+	}
+	return n.writeXML(title, indent, docs, errs)
+}
+
+// XMLTitle logs values as highlighted XML with a title line
+// Structured data logging with context
+func (n *Notifier) XMLTitle(title string, values ...any) {
+	n.XMLIndent(title, IndentSpace2, values...)
+}
+
+// writeXML formats each document and writes them in one block
+// Returns errs joined with any formatting errors
+func (n *Notifier) writeXML(title string, indent string, docs [][]byte, errs []error) error {
+	if title != "" {
+		n.Inlinef(DebugLevel, "%s: XML ↴↴", title)
+	}
+
+	var out bytes.Buffer
+	for _, doc := range docs {
+		formatted, err := formatXML(doc, indent)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to format XML: %w", err))
+			continue
+		}
+		out.Write(limitDump(formatted))
+		out.WriteByte('\n')
+	}
+	out.WriteByte('\n')
+	n.writeBlock(out.String())
+	return errors.Join(errs...)
+}
+
+// formatXML re-indents and colorizes an XML document
+// Elements holding only text stay on a single line
+// Namespace prefixes are preserved as written
+func formatXML(data []byte, indent string) ([]byte, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var tokens []xml.Token
+	for {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, xml.CopyToken(tok))
+	}
+
+	var b bytes.Buffer
+	depth := 0
+	newline := func() {
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		if indent != "" {
+			b.WriteString(strings.Repeat(indent, depth))
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i].(type) {
+		case xml.StartElement:
+			newline()
+			b.WriteString(xmlStart(tok))
+
+			// Collapse <a>text</a> and empty elements onto one line
+			if i+1 < len(tokens) {
+				if _, ok := tokens[i+1].(xml.EndElement); ok {
+					b.WriteString(xmlEnd(tok.Name))
+					i++
+					continue
+				}
+			}
+			if i+2 < len(tokens) {
+				text, isText := tokens[i+1].(xml.CharData)
+				if _, isEnd := tokens[i+2].(xml.EndElement); isText && isEnd {
+					xml.EscapeText(&b, text)
+					b.WriteString(xmlEnd(tok.Name))
+					i += 2
+					continue
+				}
+			}
+			depth++
+		case xml.EndElement:
+			depth = max(depth-1, 0)
+			newline()
+			b.WriteString(xmlEnd(tok.Name))
+		case xml.CharData:
+			text := bytes.TrimSpace(tok)
+			if len(text) == 0 {
+				continue
+			}
+			newline()
+			xml.EscapeText(&b, text)
+		case xml.Comment:
+			newline()
+			b.WriteString(xmlCommentColor.Sprintf("<!--%s-->", tok))
+		case xml.ProcInst:
+			newline()
+			b.WriteString(xmlCommentColor.Sprintf("<?%s %s?>", tok.Target, tok.Inst))
+		case xml.Directive:
+			newline()
+			b.WriteString(xmlCommentColor.Sprintf("<!%s>", tok))
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// xmlName renders a raw token name including its namespace prefix
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// xmlStart renders a colorized opening tag with its attributes
+func xmlStart(tok xml.StartElement) string {
+	var b strings.Builder
+	b.WriteString(xmlTagColor.Sprint("<" + xmlName(tok.Name)))
+	for _, attr := range tok.Attr {
+		var value bytes.Buffer
+		xml.EscapeText(&value, []byte(attr.Value))
+		b.WriteString(" ")
+		b.WriteString(xmlAttrColor.Sprint(xmlName(attr.Name)))
+		b.WriteString("=")
+		b.WriteString(xmlValueColor.Sprint(`"` + value.String() + `"`))
+	}
+	b.WriteString(xmlTagColor.Sprint(">"))
+	return b.String()
+}
+
+// xmlEnd renders a colorized closing tag
+func xmlEnd(name xml.Name) string {
+	return xmlTagColor.Sprint("</" + xmlName(name) + ">")
+}
+
+// XML logs values as highlighted XML using the default Notifier
+// Structured data logging for XML based APIs
+func XML(values ...any) { Default.XML(values...) }
+
+// XMLBytes pretty-prints an XML document using the default Notifier
+// Convenient for raw payloads
+func XMLBytes(b []byte) { Default.XMLBytes(b) }
+
+// XMLE logs values as XML using the default Notifier and returns errors
+// Structured data logging for callers that handle failures
+func XMLE(values ...any) error { return Default.XMLE(values...) }
+
+// XMLIndent logs values as XML with custom indentation using the default Notifier
+// Full control over indentation style
+func XMLIndent(title string, indent string, values ...any) {
+	Default.XMLIndent(title, indent, values...)
+}
+
+// XMLTitle logs values as XML with a title using the default Notifier
+// Structured data logging with title for context
+func XMLTitle(title string, values ...any) { Default.XMLTitle(title, values...) }